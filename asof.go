@@ -0,0 +1,287 @@
+package csv
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// AsOfDirection defines which row of the right Table an as-of join matches.
+type AsOfDirection int
+
+const (
+	// Backward matches the nearest row with a value on or before the left value.
+	Backward = AsOfDirection(iota)
+	// Forward matches the nearest row with a value on or after the left value.
+	Forward
+	// Nearest matches the nearest row in either direction, the preceding one when both are as near.
+	Nearest
+)
+
+// OrderedType defines how values compared by AsOfJoin and IntervalJoin are typed. Type is number, integer, date
+// or datetime, number by default, and Format is a format of date and datetime as in SchemaField.
+type OrderedType struct {
+	Type   string
+	Format string
+}
+
+// field returns a SchemaField which parses values of t.
+func (t OrderedType) field() (SchemaField, error) {
+	f := SchemaField{Type: t.Type, Format: t.Format}
+	switch f.Type {
+	case "":
+		f.Type = "number"
+	case "number", "integer", "date", "datetime":
+	default:
+		return f, fmt.Errorf("values of type %s cannot be ordered", t.Type)
+	}
+	if f.Format == "any" {
+		return f, fmt.Errorf("values of format any cannot be ordered")
+	}
+	return f, nil
+}
+
+// tolerance parses the maximum distance of matched values, a number or a duration like 72h for dates.
+// An empty value means no limit.
+func tolerance(f SchemaField, v string) (float64, error) {
+	if v == "" {
+		return -1, nil
+	}
+	if f.Type == "date" || f.Type == "datetime" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("tolerance %q is not a positive duration", v)
+		}
+		return float64(d), nil
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("tolerance %q is not a positive number", v)
+	}
+	return n, nil
+}
+
+// distance returns how far apart two values parsed by the same field are, in nanoseconds for times.
+func distance(a, b any) float64 {
+	var d float64
+	switch p := a.(type) {
+	case float64:
+		d = p - b.(float64)
+	case time.Time:
+		d = float64(p.Sub(b.(time.Time)))
+	}
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// orderedRow is a row of the right Table with its parsed value.
+type orderedRow struct {
+	value any
+	end   any
+	row   int
+}
+
+// partitionRows indexes rows of the right Table by the partition key columns. Values of the column at col,
+// and of the column at end when it is not negative, are parsed by f; rows with a null value at col are skipped
+// and a null value at end means no end. Rows of each partition are ordered by their values.
+func partitionRows(right *Table, keys []int, col, end int, f SchemaField) (map[string][]orderedRow, error) {
+	isNull := right.nullChecker()
+	names := right.titles.names()
+	index := make(map[string][]orderedRow)
+	for r, row := range right.rows {
+		if hasNullKey(isNull, row, keys) || nullAt(isNull, col, row[col]) {
+			continue
+		}
+		o := orderedRow{row: r}
+		var err error
+		if o.value, err = f.parse(row[col]); err != nil {
+			return nil, fmt.Errorf("row %d of right table, column %s: %w", r, names[col], err)
+		}
+		if end >= 0 && !nullAt(isNull, end, row[end]) {
+			if o.end, err = f.parse(row[end]); err != nil {
+				return nil, fmt.Errorf("row %d of right table, column %s: %w", r, names[end], err)
+			}
+		}
+		k := rowKey(row, keys)
+		index[k] = append(index[k], o)
+	}
+	for _, rows := range index {
+		sort.SliceStable(rows, func(i, j int) bool {
+			order, _ := compareParsed(rows[i].value, rows[j].value)
+			return order < 0
+		})
+	}
+	return index, nil
+}
+
+// AsOfOptions configures AsOfJoin. Kind of JoinOptions is InnerJoin or LeftJoin and RightKeys are the partition
+// columns of the right Table. RightOn is the compared column of the right Table, the same as the left one when it
+// is empty. Tolerance is the maximum distance of matched values, a number or a duration like 72h for dates.
+type AsOfOptions struct {
+	JoinOptions
+	OrderedType
+	Direction AsOfDirection
+	RightOn   string
+	Tolerance string
+}
+
+// AsOfJoin relates each row of the Table with the row of the right Table whose value of the compared column is
+// the nearest one by opts.Direction, e.g. the latest exchange rate on or before a transaction date. Rows are
+// matched within partitions made of the named columns. The result has the columns of a Join by the partition
+// columns, so the compared columns of both sides are kept. A left row with a null value never matches.
+func (p *Table) AsOfJoin(right *Table, on string, partition []string, opts AsOfOptions) (*Table, error) {
+	if opts.Kind != InnerJoin && opts.Kind != LeftJoin {
+		return nil, fmt.Errorf("failed to execute AsOfJoin method: only inner and left joins are supported")
+	}
+	if opts.Direction < Backward || opts.Direction > Nearest {
+		return nil, fmt.Errorf("failed to execute AsOfJoin method: unknown direction %d", opts.Direction)
+	}
+	l, err := newJoinLayout(p, right, partition, opts.JoinOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute AsOfJoin method: %w", err)
+	}
+	rightOn := opts.RightOn
+	if rightOn == "" {
+		rightOn = on
+	}
+	col, exists := p.titles[on]
+	if !exists {
+		return nil, fmt.Errorf("failed to execute AsOfJoin method: %w", TitleNotFound(on))
+	}
+	rightCol, exists := right.titles[rightOn]
+	if !exists {
+		return nil, fmt.Errorf("failed to execute AsOfJoin method: right table: %w", TitleNotFound(rightOn))
+	}
+	f, err := opts.field()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute AsOfJoin method: %w", err)
+	}
+	limit, err := tolerance(f, opts.Tolerance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute AsOfJoin method: %w", err)
+	}
+	index, err := partitionRows(right, l.rightKeys, rightCol, -1, f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute AsOfJoin method: %w", err)
+	}
+
+	isNull := p.nullChecker()
+	var rows [][]string
+	for r, row := range p.rows {
+		match := -1
+		if !hasNullKey(isNull, row, l.leftKeys) && !nullAt(isNull, col, row[col]) {
+			v, err := f.parse(row[col])
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute AsOfJoin method: row %d, column %s: %w", r, on, err)
+			}
+			match = asOfMatch(index[rowKey(row, l.leftKeys)], v, opts.Direction, limit)
+		}
+		switch {
+		case match >= 0:
+			rows = append(rows, l.row(row, right.rows[match]))
+		case opts.Kind == LeftJoin:
+			rows = append(rows, l.row(row, nil))
+		}
+	}
+	return &Table{titles: createTitle(l.titles), rows: rows}, nil
+}
+
+// asOfMatch returns the row of ordered which value is the nearest one to v by direction within limit,
+// -1 when there is none. Among rows with the same value, the last one is preceding and the first one is following.
+func asOfMatch(ordered []orderedRow, v any, direction AsOfDirection, limit float64) int {
+	// after is the first row with a value greater than v, and from is the first one not less than v
+	after := sort.Search(len(ordered), func(i int) bool {
+		order, _ := compareParsed(ordered[i].value, v)
+		return order > 0
+	})
+	from := sort.Search(len(ordered), func(i int) bool {
+		order, _ := compareParsed(ordered[i].value, v)
+		return order >= 0
+	})
+	var candidates []int
+	if direction != Forward && after > 0 {
+		candidates = append(candidates, after-1)
+	}
+	if direction != Backward && from < len(ordered) {
+		candidates = append(candidates, from)
+	}
+
+	best, bestDistance := -1, 0.0
+	for _, i := range candidates {
+		d := distance(ordered[i].value, v)
+		if (limit < 0 || d <= limit) && (best < 0 || d < bestDistance) {
+			best, bestDistance = ordered[i].row, d
+		}
+	}
+	return best
+}
+
+// IntervalOptions configures IntervalJoin. Kind of JoinOptions is InnerJoin or LeftJoin and RightKeys are the
+// partition columns of the right Table. When ExclusiveEnd is true, a value equal to the end is not in a range.
+type IntervalOptions struct {
+	JoinOptions
+	OrderedType
+	ExclusiveEnd bool
+}
+
+// IntervalJoin relates each row of the Table with the rows of the right Table whose range from the column from
+// to the column to contains the value of the compared column, e.g. the tariff valid on a date. Rows are matched
+// within partitions made of the named columns. A null end means the range has no end. Matches of a row are in
+// the order of the starts of their ranges. The result has the columns of a Join by the partition columns.
+func (p *Table) IntervalJoin(right *Table, on, from, to string, partition []string, opts IntervalOptions) (*Table, error) {
+	if opts.Kind != InnerJoin && opts.Kind != LeftJoin {
+		return nil, fmt.Errorf("failed to execute IntervalJoin method: only inner and left joins are supported")
+	}
+	l, err := newJoinLayout(p, right, partition, opts.JoinOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute IntervalJoin method: %w", err)
+	}
+	col, exists := p.titles[on]
+	if !exists {
+		return nil, fmt.Errorf("failed to execute IntervalJoin method: %w", TitleNotFound(on))
+	}
+	bounds, err := right.titles.indexes([]string{from, to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute IntervalJoin method: right table: %w", err)
+	}
+	f, err := opts.field()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute IntervalJoin method: %w", err)
+	}
+	index, err := partitionRows(right, l.rightKeys, bounds[0], bounds[1], f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute IntervalJoin method: %w", err)
+	}
+
+	isNull := p.nullChecker()
+	var rows [][]string
+	for r, row := range p.rows {
+		matched := false
+		if !hasNullKey(isNull, row, l.leftKeys) && !nullAt(isNull, col, row[col]) {
+			v, err := f.parse(row[col])
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute IntervalJoin method: row %d, column %s: %w", r, on, err)
+			}
+			for _, o := range index[rowKey(row, l.leftKeys)] {
+				if order, _ := compareParsed(o.value, v); order > 0 {
+					break
+				}
+				if o.end != nil {
+					order, _ := compareParsed(v, o.end)
+					if order > 0 || (order == 0 && opts.ExclusiveEnd) {
+						continue
+					}
+				}
+				matched = true
+				rows = append(rows, l.row(row, right.rows[o.row]))
+			}
+		}
+		if !matched && opts.Kind == LeftJoin {
+			rows = append(rows, l.row(row, nil))
+		}
+	}
+	return &Table{titles: createTitle(l.titles), rows: rows}, nil
+}
@@ -0,0 +1,161 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_AsOfJoin(t *testing.T) {
+	trades := func() *Table {
+		return &Table{
+			titles: createTitle([]string{"currency", "date", "amount"}),
+			rows: [][]string{
+				{"USD", "2024-01-03", "100"},
+				{"EUR", "2024-01-01", "50"},
+				{"USD", "2024-01-10", "70"},
+				{"EUR", "2024-01-02", "20"},
+			},
+		}
+	}
+	rates := &Table{
+		titles: createTitle([]string{"currency", "day", "rate"}),
+		rows: [][]string{
+			{"USD", "2024-01-02", "0.79"},
+			{"USD", "2024-01-04", "0.78"},
+			{"EUR", "2024-01-02", "0.86"},
+			{"USD", "2024-01-01", "0.80"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts AsOfOptions
+		want [][]string
+	}{
+		{
+			name: "Backward",
+			opts: AsOfOptions{OrderedType: OrderedType{Type: "date"}, RightOn: "day"},
+			want: [][]string{
+				{"USD", "2024-01-03", "100", "2024-01-02", "0.79"},
+				{"USD", "2024-01-10", "70", "2024-01-04", "0.78"},
+				{"EUR", "2024-01-02", "20", "2024-01-02", "0.86"},
+			},
+		},
+		{
+			name: "Forward with tolerance",
+			opts: AsOfOptions{JoinOptions: JoinOptions{Kind: LeftJoin, Fill: "-"}, OrderedType: OrderedType{Type: "date"}, RightOn: "day", Direction: Forward, Tolerance: "48h"},
+			want: [][]string{
+				{"USD", "2024-01-03", "100", "2024-01-04", "0.78"},
+				{"EUR", "2024-01-01", "50", "2024-01-02", "0.86"},
+				{"USD", "2024-01-10", "70", "-", "-"},
+				{"EUR", "2024-01-02", "20", "2024-01-02", "0.86"},
+			},
+		},
+		{
+			name: "Nearest",
+			opts: AsOfOptions{OrderedType: OrderedType{Type: "date"}, RightOn: "day", Direction: Nearest, Tolerance: "24h"},
+			want: [][]string{
+				{"USD", "2024-01-03", "100", "2024-01-02", "0.79"},
+				{"EUR", "2024-01-01", "50", "2024-01-02", "0.86"},
+				{"EUR", "2024-01-02", "20", "2024-01-02", "0.86"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := trades().AsOfJoin(rates, "date", []string{"currency"}, tt.opts)
+			if err != nil {
+				t.Fatalf("AsOfJoin failed: %s", err)
+			}
+			if names := got.titles.names(); !reflect.DeepEqual(names, []string{"currency", "date", "amount", "day", "rate"}) {
+				t.Errorf("AsOfJoin titles = %v", names)
+			}
+			if !reflect.DeepEqual(got.rows, tt.want) {
+				t.Errorf("AsOfJoin rows = %v, want %v", got.rows, tt.want)
+			}
+		})
+	}
+
+	t.Run("Numbers without partition", func(t *testing.T) {
+		left := &Table{titles: createTitle([]string{"x"}), rows: [][]string{{"9"}, {"10.5"}, {"-1"}}}
+		right := &Table{titles: createTitle([]string{"x", "label"}), rows: [][]string{{"10", "ten"}, {"2", "two"}}}
+		got, err := left.AsOfJoin(right, "x", nil, AsOfOptions{JoinOptions: JoinOptions{Kind: LeftJoin}})
+		if err != nil {
+			t.Fatalf("AsOfJoin failed: %s", err)
+		}
+		want := [][]string{{"9", "2", "two"}, {"10.5", "10", "ten"}, {"-1", "", ""}}
+		if !reflect.DeepEqual(got.rows, want) {
+			t.Errorf("AsOfJoin rows = %v, want %v", got.rows, want)
+		}
+		if names := got.titles.names(); !reflect.DeepEqual(names, []string{"x_left", "x_right", "label"}) {
+			t.Errorf("AsOfJoin titles = %v", names)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		p := trades()
+		if _, err := p.AsOfJoin(rates, "date", []string{"currency"}, AsOfOptions{}); err == nil {
+			t.Error("AsOfJoin should fail on a compared column missing in the right table")
+		}
+		if _, err := p.AsOfJoin(rates, "date", []string{"currency"}, AsOfOptions{RightOn: "day"}); err == nil {
+			t.Error("AsOfJoin should fail on dates parsed as numbers")
+		}
+		if _, err := p.AsOfJoin(rates, "date", []string{"currency"}, AsOfOptions{OrderedType: OrderedType{Type: "string"}, RightOn: "day"}); err == nil {
+			t.Error("AsOfJoin should fail on an unordered type")
+		}
+		if _, err := p.AsOfJoin(rates, "date", nil, AsOfOptions{JoinOptions: JoinOptions{Kind: FullJoin}, RightOn: "day"}); err == nil {
+			t.Error("AsOfJoin should fail on a full join")
+		}
+	})
+}
+
+func TestTable_IntervalJoin(t *testing.T) {
+	shipments := &Table{
+		titles: createTitle([]string{"id", "zone", "shipped"}),
+		rows: [][]string{
+			{"1", "A", "2024-03-31"},
+			{"2", "A", "2024-04-01"},
+			{"3", "B", "2024-01-15"},
+			{"4", "C", "2024-01-15"},
+			{"5", "B", ""},
+		},
+	}
+	shipments.SetNulls([]string{""})
+	tariffs := &Table{
+		titles: createTitle([]string{"zone", "valid_from", "valid_to", "price"}),
+		rows: [][]string{
+			{"A", "2024-04-01", "", "12"},
+			{"A", "2024-01-01", "2024-04-01", "10"},
+			{"B", "2024-01-01", "2024-06-30", "7"},
+		},
+	}
+	tariffs.SetNulls([]string{""})
+
+	got, err := shipments.IntervalJoin(tariffs, "shipped", "valid_from", "valid_to", []string{"zone"},
+		IntervalOptions{JoinOptions: JoinOptions{Kind: LeftJoin}, OrderedType: OrderedType{Type: "date"}, ExclusiveEnd: true})
+	if err != nil {
+		t.Fatalf("IntervalJoin failed: %s", err)
+	}
+	want := [][]string{
+		{"A", "1", "2024-03-31", "2024-01-01", "2024-04-01", "10"},
+		{"A", "2", "2024-04-01", "2024-04-01", "", "12"},
+		{"B", "3", "2024-01-15", "2024-01-01", "2024-06-30", "7"},
+		{"C", "4", "2024-01-15", "", "", ""},
+		{"B", "5", "", "", "", ""},
+	}
+	if !reflect.DeepEqual(got.rows, want) {
+		t.Errorf("IntervalJoin rows = %v, want %v", got.rows, want)
+	}
+
+	got, err = shipments.IntervalJoin(tariffs, "shipped", "valid_from", "valid_to", []string{"zone"}, IntervalOptions{OrderedType: OrderedType{Type: "date"}})
+	if err != nil {
+		t.Fatalf("IntervalJoin failed: %s", err)
+	}
+	if len(got.rows) != 4 {
+		t.Errorf("IntervalJoin with inclusive ends should match 2024-04-01 twice, but got %v", got.rows)
+	}
+
+	if _, err = shipments.IntervalJoin(tariffs, "shipped", "valid_from", "missing", nil, IntervalOptions{}); err == nil {
+		t.Error("IntervalJoin should fail on a missing range column")
+	}
+}
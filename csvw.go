@@ -0,0 +1,370 @@
+package csv
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/exp/slices"
+)
+
+// CSV on the Web (CSVW) metadata is defined by W3C: https://www.w3.org/TR/tabular-metadata/
+// Only a single table, or a table of a table group, described by a local metadata file is supported.
+
+const (
+	csvwContext      = "http://www.w3.org/ns/csvw"
+	metadataSuffix   = "-metadata.json"
+	metadataFileName = "csv-metadata.json"
+)
+
+// csvwTypes maps CSVW datatypes to Table Schema types, the others are treated as strings.
+var csvwTypes = map[string]string{
+	"integer":            "integer",
+	"int":                "integer",
+	"long":               "integer",
+	"short":              "integer",
+	"byte":               "integer",
+	"nonNegativeInteger": "integer",
+	"nonPositiveInteger": "integer",
+	"positiveInteger":    "integer",
+	"negativeInteger":    "integer",
+	"unsignedLong":       "integer",
+	"unsignedInt":        "integer",
+	"unsignedShort":      "integer",
+	"unsignedByte":       "integer",
+	"decimal":            "number",
+	"double":             "number",
+	"float":              "number",
+	"number":             "number",
+	"boolean":            "boolean",
+	"date":               "date",
+	"dateTime":           "datetime",
+	"datetime":           "datetime",
+	"time":               "time",
+	"gYear":              "year",
+}
+
+// schemaTypes maps Table Schema types to CSVW datatypes when emitting metadata.
+var schemaTypes = map[string]string{
+	"integer":  "integer",
+	"number":   "number",
+	"boolean":  "boolean",
+	"date":     "date",
+	"datetime": "dateTime",
+	"time":     "time",
+	"year":     "gYear",
+}
+
+// CSVWMetadata describes a csv file. When it is read from a table group, only the matched table is kept.
+type CSVWMetadata struct {
+	Context     any          `json:"@context"`
+	URL         string       `json:"url"`
+	Dialect     *CSVWDialect `json:"dialect,omitempty"`
+	TableSchema CSVWSchema   `json:"tableSchema"`
+}
+
+// CSVWDialect describes how to parse a csv file. Different to the specification, comments are not
+// recognised unless CommentPrefix is set. Trim can be a boolean or one of "true", "false", "start" and "end",
+// only trimming at start is supported.
+type CSVWDialect struct {
+	Delimiter      string `json:"delimiter,omitempty"`
+	QuoteChar      string `json:"quoteChar,omitempty"`
+	Header         *bool  `json:"header,omitempty"`
+	HeaderRowCount *int   `json:"headerRowCount,omitempty"`
+	SkipRows       int    `json:"skipRows,omitempty"`
+	CommentPrefix  string `json:"commentPrefix,omitempty"`
+	Trim           any    `json:"trim,omitempty"`
+	Encoding       string `json:"encoding,omitempty"`
+}
+
+// CSVWSchema describes columns of a csv file.
+type CSVWSchema struct {
+	Columns    []CSVWColumn `json:"columns"`
+	PrimaryKey FieldNames   `json:"primaryKey,omitempty"`
+}
+
+// CSVWColumn describes a column. Null is nil means the default of the specification, an empty string.
+// Virtual columns do not exist in csv files, so they are ignored.
+type CSVWColumn struct {
+	Name     string       `json:"name,omitempty"`
+	Titles   CSVWTitles   `json:"titles,omitempty"`
+	Datatype CSVWDatatype `json:"datatype,omitempty"`
+	Null     FieldNames   `json:"null,omitempty"`
+	Required bool         `json:"required,omitempty"`
+	Virtual  bool         `json:"virtual,omitempty"`
+}
+
+// CSVWTitles are titles of a column. It can be written as a string, an array of strings or
+// an object of language tags to a string or an array of strings.
+type CSVWTitles []string
+
+// UnmarshalJSON accepts all forms of titles, titles of all languages are kept.
+func (t *CSVWTitles) UnmarshalJSON(data []byte) error {
+	var names FieldNames
+	if err := json.Unmarshal(data, &names); err == nil {
+		*t = CSVWTitles(names)
+		return nil
+	}
+	var languages map[string]FieldNames
+	if err := json.Unmarshal(data, &languages); err != nil {
+		return fmt.Errorf("titles should be a string, an array of strings or an object of them: %w", err)
+	}
+	tags := make([]string, 0, len(languages))
+	for tag := range languages {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		*t = append(*t, languages[tag]...)
+	}
+	return nil
+}
+
+// CSVWDatatype is the datatype of a column, it can be written as the name of a datatype or an object with a base.
+type CSVWDatatype struct {
+	Base   string `json:"base,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+// UnmarshalJSON accepts a string and an object.
+func (d *CSVWDatatype) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &d.Base); err == nil {
+		return nil
+	}
+	type datatype CSVWDatatype
+	return json.Unmarshal(data, (*datatype)(d))
+}
+
+// MarshalJSON writes the name of the datatype when there is no format.
+func (d CSVWDatatype) MarshalJSON() ([]byte, error) {
+	if d.Format == "" {
+		return json.Marshal(d.Base)
+	}
+	type datatype CSVWDatatype
+	return json.Marshal(datatype(d))
+}
+
+// csvwDocument is either a table or a table group.
+type csvwDocument struct {
+	CSVWMetadata
+	Tables []CSVWMetadata `json:"tables"`
+}
+
+// DiscoverMetadata looks for a CSVW metadata document of the csv file named by fileName: first
+// fileName-metadata.json and then csv-metadata.json in the same directory. The document is only used
+// when its url refers to the csv file. It returns nil without an error when no document is found.
+func DiscoverMetadata(fileName string) (*CSVWMetadata, error) {
+	dir := filepath.Dir(fileName)
+	for _, candidate := range []string{fileName + metadataSuffix, filepath.Join(dir, metadataFileName)} {
+		content, err := os.ReadFile(candidate)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var doc csvwDocument
+		if err = json.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", candidate, err)
+		}
+		tables := doc.Tables
+		if len(tables) == 0 {
+			tables = []CSVWMetadata{doc.CSVWMetadata}
+		}
+		for i := range tables {
+			if filepath.Clean(filepath.Join(dir, filepath.FromSlash(tables[i].URL))) == filepath.Clean(fileName) {
+				if tables[i].Dialect == nil {
+					tables[i].Dialect = doc.Dialect
+				}
+				return &tables[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Load reads the csv file named by fileName according to the metadata. Titles of the Table are the names
+// of columns, nulls of columns are set, numeric columns are set as decimal columns and values are validated
+// against datatypes, required and primary key.
+func (m *CSVWMetadata) Load(fileName string) (*Table, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := csv.NewReader(file)
+	skip, headers, err := m.Dialect.configure(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < skip+headers {
+		return nil, fmt.Errorf("%s has fewer rows than skipped and header rows", fileName)
+	}
+	var header []string
+	if headers > 0 {
+		header = records[skip]
+	}
+	records = records[skip+headers:]
+
+	var columns []CSVWColumn
+	for _, c := range m.TableSchema.Columns {
+		if !c.Virtual {
+			columns = append(columns, c)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("%s: metadata has no columns", fileName)
+	}
+	if header != nil && len(header) != len(columns) {
+		return nil, fmt.Errorf("%s: metadata has %d columns, but the header has %d", fileName, len(columns), len(header))
+	}
+	// the reader does not check lengths of rows because of header rows, so data rows are checked here
+	for r, record := range records {
+		if len(record) != len(columns) {
+			return nil, fmt.Errorf("%s: metadata has %d columns, but data row %d has %d", fileName, len(columns), r+1, len(record))
+		}
+	}
+
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		if header != nil && len(c.Titles) > 0 && !slices.Contains(c.Titles, header[i]) {
+			return nil, fmt.Errorf("%s: title %q of column %d is not one of %v", fileName, header[i], i+1, c.Titles)
+		}
+		switch {
+		case c.Name != "":
+			names[i] = c.Name
+		case len(c.Titles) > 0:
+			names[i] = c.Titles[0]
+		case header != nil:
+			names[i] = header[i]
+		default:
+			names[i] = "_col." + strconv.Itoa(i+1)
+		}
+	}
+
+	p := &Table{titles: createTitle(names), rows: records}
+	schema := &TableSchema{PrimaryKey: m.TableSchema.PrimaryKey}
+	for i, c := range columns {
+		tokens := []string(c.Null)
+		if tokens == nil {
+			tokens = []string{""}
+		}
+		if err = p.SetColumnNulls(names[i], tokens); err != nil {
+			return nil, err
+		}
+		schema.Fields = append(schema.Fields, c.field(names[i]))
+	}
+	if err = schema.validate(m.URL, p, p.nullChecker()); err != nil {
+		return nil, err
+	}
+	if err = schema.setDecimalColumns(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// field converts a column to a SchemaField for validation.
+func (c CSVWColumn) field(name string) SchemaField {
+	f := SchemaField{Name: name, Type: csvwTypes[c.Datatype.Base]}
+	if f.Type == "boolean" && c.Datatype.Format != "" {
+		// format of boolean is "true values|false values"
+		if values := strings.SplitN(c.Datatype.Format, "|", 2); len(values) == 2 {
+			f.TrueValues, f.FalseValues = []string{values[0]}, []string{values[1]}
+		}
+	}
+	if c.Required {
+		f.Constraints = &FieldConstraints{Required: true}
+	}
+	return f
+}
+
+// configure applies the dialect to a csv.Reader, it returns the numbers of rows to skip and of header rows.
+func (d *CSVWDialect) configure(r *csv.Reader) (skip, headers int, err error) {
+	headers = 1
+	if d == nil {
+		return 0, headers, nil
+	}
+	if d.Encoding != "" && !strings.EqualFold(d.Encoding, "utf-8") {
+		return 0, 0, fmt.Errorf("encoding %s is not supported", d.Encoding)
+	}
+	if d.QuoteChar != "" && d.QuoteChar != `"` {
+		return 0, 0, fmt.Errorf("quote character %s is not supported", d.QuoteChar)
+	}
+	if d.Delimiter != "" {
+		if utf8.RuneCountInString(d.Delimiter) != 1 {
+			return 0, 0, fmt.Errorf("delimiter %q should be a single character", d.Delimiter)
+		}
+		r.Comma, _ = utf8.DecodeRuneInString(d.Delimiter)
+	}
+	if d.CommentPrefix != "" {
+		if utf8.RuneCountInString(d.CommentPrefix) != 1 {
+			return 0, 0, fmt.Errorf("comment prefix %q should be a single character", d.CommentPrefix)
+		}
+		r.Comment, _ = utf8.DecodeRuneInString(d.CommentPrefix)
+	}
+	switch d.Trim {
+	case true, "true", "start":
+		r.TrimLeadingSpace = true
+	}
+	if d.Header != nil && !*d.Header {
+		headers = 0
+	}
+	if d.HeaderRowCount != nil {
+		headers = *d.HeaderRowCount
+	}
+	// rows may have different lengths when there are more than one header rows
+	r.FieldsPerRecord = -1
+	return d.SkipRows, headers, nil
+}
+
+// Metadata creates CSVW metadata for the Table written to url. Datatypes are inferred as InferSchema does,
+// and the null tokens of each column, set for the column or else for the Table, are the nulls of the column.
+func (p *Table) Metadata(url string) *CSVWMetadata {
+	schema := InferSchema(p)
+	m := &CSVWMetadata{Context: csvwContext, URL: url}
+	for _, f := range schema.Fields {
+		c := CSVWColumn{Name: f.Name, Titles: CSVWTitles{f.Name}, Datatype: CSVWDatatype{Base: "string"}, Null: p.columnNulls(f.Name)}
+		if t, ok := schemaTypes[f.Type]; ok {
+			c.Datatype.Base = t
+		}
+		m.TableSchema.Columns = append(m.TableSchema.Columns, c)
+	}
+	return m
+}
+
+// WriteMetadata writes CSVW metadata of the Table written to url into w.
+func (p *Table) WriteMetadata(w io.Writer, url string) error {
+	content, err := json.MarshalIndent(p.Metadata(url), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(content, '\n'))
+	return err
+}
+
+// SaveWithMetadata writes the Table to a csv file named by fileName and its CSVW metadata
+// to fileName-metadata.json, so LoadTable can discover it.
+func (p *Table) SaveWithMetadata(fileName string) error {
+	if err := writeFile(fileName, p); err != nil {
+		return err
+	}
+	f, err := os.Create(fileName + metadataSuffix)
+	if err != nil {
+		return err
+	}
+	return errors.Join(p.WriteMetadata(f, filepath.Base(fileName)), f.Close())
+}
@@ -0,0 +1,115 @@
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const csvwMetadata = `{
+  "@context": "http://www.w3.org/ns/csvw",
+  "url": "scores.csv",
+  "dialect": {"delimiter": ";", "skipRows": 1},
+  "tableSchema": {
+    "columns": [
+      {"name": "user", "titles": {"en": "User name"}, "required": true},
+      {"name": "score", "titles": "Score", "datatype": {"base": "integer"}, "null": ["-", "NA"]},
+      {"name": "source", "virtual": true}
+    ],
+    "primaryKey": "user"
+  }
+}`
+
+func writeCSVW(t *testing.T, content string) string {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "scores.csv")
+	if err := os.WriteFile(fileName, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fileName+metadataSuffix, []byte(csvwMetadata), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return fileName
+}
+
+func TestLoadTable_csvw(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		p, err := LoadTable(writeCSVW(t, "exported by a tool\nUser name;Score\nrob;100\nken;-\ngri;NA\n"))
+		if err != nil {
+			t.Fatalf("LoadTable failed: %s", err)
+		}
+		if _, ok := p.titles["score"]; !ok || len(p.rows) != 3 {
+			t.Errorf("Want titles from column names and 3 rows, but got %v and %d rows", p.titles, len(p.rows))
+		}
+		isNull, _ := p.IsNull("score")
+		if isNull(p.rows[0]) || !isNull(p.rows[1]) || !isNull(p.rows[2]) {
+			t.Errorf("Nulls of score column are not applied")
+		}
+		if _, ok := p.decimals["score"]; !ok || len(p.decimals) != 1 {
+			t.Errorf("Want score as the only decimal column, but got %v", p.decimals)
+		}
+	})
+
+	for name, content := range map[string]string{
+		"Wrong type":        "skipped\nUser name;Score\nrob;one hundred\n",
+		"Required":          "skipped\nUser name;Score\n;100\n",
+		"Duplicated key":    "skipped\nUser name;Score\nrob;100\nrob;80\n",
+		"Wrong title":       "skipped\nUser;Score\nrob;100\n",
+		"Number of columns": "skipped\nUser name;Score;Extra\nrob;100;1\n",
+		"Short row":         "skipped\nUser name;Score\nrob;100\nken\n",
+		"Long row":          "skipped\nUser name;Score\nrob;100\nken;1;2\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := LoadTable(writeCSVW(t, content)); err == nil {
+				t.Error("LoadTable should fail")
+			}
+		})
+	}
+}
+
+func TestTable_SaveWithMetadata(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		p, err := LoadTable(writeCSVW(t, "exported by a tool\nUser name;Score\nrob;100\nken;-\ngri;NA\n"))
+		if err != nil {
+			t.Fatalf("LoadTable failed: %s", err)
+		}
+		fileName := filepath.Join(t.TempDir(), "saved.csv")
+		if err = p.SaveWithMetadata(fileName); err != nil {
+			t.Fatalf("SaveWithMetadata failed: %s", err)
+		}
+		loaded, err := LoadTable(fileName)
+		if err != nil {
+			t.Fatalf("LoadTable of the saved table failed: %s", err)
+		}
+		isNull, _ := loaded.IsNull("score")
+		if !reflect.DeepEqual(loaded.rows, p.rows) || isNull(loaded.rows[0]) || !isNull(loaded.rows[1]) || !isNull(loaded.rows[2]) {
+			t.Errorf("Want rows %v with nulls of score column, but got %v", p.rows, loaded.rows)
+		}
+	})
+
+	fileName := filepath.Join(t.TempDir(), "scores.csv")
+	p := &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
+	p.rows[1][2] = "N/A"
+	p.SetNulls([]string{"N/A"})
+
+	if err := p.SaveWithMetadata(fileName); err != nil {
+		t.Fatalf("SaveWithMetadata failed: %s", err)
+	}
+
+	meta, err := DiscoverMetadata(fileName)
+	if err != nil || meta == nil {
+		t.Fatalf("DiscoverMetadata should find the written metadata, but got %v, %v", meta, err)
+	}
+	if meta.TableSchema.Columns[2].Datatype.Base != "integer" {
+		t.Errorf("Want integer datatype for scores, but got %s", meta.TableSchema.Columns[2].Datatype.Base)
+	}
+
+	loaded, err := LoadTable(fileName)
+	if err != nil {
+		t.Fatalf("LoadTable failed: %s", err)
+	}
+	if nCols, nRows := loaded.Size(); nCols != 3 || nRows != len(p.rows) {
+		t.Errorf("Want size 3, %d, but got %d, %d", len(p.rows), nCols, nRows)
+	}
+}
@@ -0,0 +1,592 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Data Package and Table Schema are defined by Frictionless Data: https://specs.frictionlessdata.io/
+// Only tabular data resources stored in local csv files are supported.
+
+const (
+	dataPackageFile     = "datapackage.json"
+	tabularDataResource = "tabular-data-resource"
+	schemaErrorPrefix   = "csv/SchemaError"
+)
+
+var validResourceName = regexp.MustCompile(`^[a-z0-9._-]+$`)
+
+// FieldNames is a list of field names. In a Table Schema it can be written as a single string or an array of strings.
+type FieldNames []string
+
+// UnmarshalJSON accepts both a string and an array of strings.
+func (f *FieldNames) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*f = FieldNames{name}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("field names should be a string or an array of strings: %w", err)
+	}
+	*f = names
+	return nil
+}
+
+// DataPackage describes a collection of data resources, normally it is read from a datapackage.json file.
+type DataPackage struct {
+	Name      string         `json:"name,omitempty"`
+	Resources []DataResource `json:"resources"`
+}
+
+// DataResource describes a csv file of a DataPackage. Path is relative to the directory of the descriptor,
+// and it should not lead out of the directory.
+type DataResource struct {
+	Name    string       `json:"name"`
+	Path    string       `json:"path"`
+	Profile string       `json:"profile,omitempty"`
+	Schema  *TableSchema `json:"schema,omitempty"`
+}
+
+// TableSchema describes the fields of a tabular data resource. When MissingValues is nil,
+// the default of the specification, an empty string, is used.
+type TableSchema struct {
+	Fields        []SchemaField `json:"fields"`
+	PrimaryKey    FieldNames    `json:"primaryKey,omitempty"`
+	ForeignKeys   []ForeignKey  `json:"foreignKeys,omitempty"`
+	MissingValues []string      `json:"missingValues,omitempty"`
+}
+
+// SchemaField describes a field (a column) of a Table. Types without special handling,
+// e.g. object or geopoint, are treated as strings.
+type SchemaField struct {
+	Name        string            `json:"name"`
+	Type        string            `json:"type,omitempty"`
+	Format      string            `json:"format,omitempty"`
+	TrueValues  []string          `json:"trueValues,omitempty"`
+	FalseValues []string          `json:"falseValues,omitempty"`
+	Constraints *FieldConstraints `json:"constraints,omitempty"`
+}
+
+// FieldConstraints are the constraints of a SchemaField. Minimum, Maximum and Enum have values of the type of the field.
+type FieldConstraints struct {
+	Required  bool   `json:"required,omitempty"`
+	Unique    bool   `json:"unique,omitempty"`
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Minimum   any    `json:"minimum,omitempty"`
+	Maximum   any    `json:"maximum,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	Enum      []any  `json:"enum,omitempty"`
+}
+
+// ForeignKey links fields of a resource to fields of another resource, an empty Resource means the resource itself.
+type ForeignKey struct {
+	Fields    FieldNames          `json:"fields"`
+	Reference ForeignKeyReference `json:"reference"`
+}
+
+// ForeignKeyReference is the referenced side of a ForeignKey.
+type ForeignKeyReference struct {
+	Resource string     `json:"resource"`
+	Fields   FieldNames `json:"fields"`
+}
+
+// SchemaError describes a row of a Table which does not conform to a TableSchema.
+// Row is zero-based, not counting titles, and it is -1 when the error is not about a single row.
+type SchemaError struct {
+	Resource string
+	Row      int
+	Field    string
+	Msg      string
+}
+
+func (e SchemaError) Error() string {
+	return fmt.Sprintf("%s: resource %q, row %d, field %q: %s", schemaErrorPrefix, e.Resource, e.Row, e.Field, e.Msg)
+}
+
+// ReadDataPackage reads a datapackage.json file named by fileName and loads all its resources
+// into Tables keyed by resource names. Missing values of schemas are set as nulls of Tables, and number and
+// integer fields are set as decimal columns. Loaded Tables are not validated, use DataPackage.Validate.
+func ReadDataPackage(fileName string) (*DataPackage, map[string]*Table, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dp := &DataPackage{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err = decoder.Decode(dp); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", fileName, err)
+	}
+
+	dir := filepath.Dir(fileName)
+	tables := make(map[string]*Table, len(dp.Resources))
+	for _, r := range dp.Resources {
+		if r.Path == "" {
+			return nil, nil, fmt.Errorf("resource %q: path should be a relative path to a csv file", r.Name)
+		}
+		// paths should not lead out of the directory of the descriptor
+		path, err := filePath(dir, filepath.FromSlash(r.Path))
+		if err != nil {
+			return nil, nil, fmt.Errorf("resource %q: %w", r.Name, err)
+		}
+		p, err := loadTable(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resource %q: %w", r.Name, err)
+		}
+		if r.Schema != nil {
+			p.SetNulls(r.Schema.missingValues())
+			if err = r.Schema.setDecimalColumns(p); err != nil {
+				return nil, nil, fmt.Errorf("resource %q: %w", r.Name, err)
+			}
+		}
+		tables[r.Name] = p
+	}
+	return dp, tables, nil
+}
+
+// Validate checks tables against schemas of resources, including types, constraints, primary keys and foreign keys.
+// All violations are joined in the returned error.
+func (dp *DataPackage) Validate(tables map[string]*Table) error {
+	var errs []error
+	for _, r := range dp.Resources {
+		p, ok := tables[r.Name]
+		if !ok {
+			errs = append(errs, SchemaError{r.Name, -1, "", "table of the resource is missing"})
+			continue
+		}
+		if r.Schema == nil {
+			continue
+		}
+		if err := r.Schema.validate(r.Name, p, r.Schema.nullChecker()); err != nil {
+			errs = append(errs, err)
+		}
+		for _, fk := range r.Schema.ForeignKeys {
+			errs = append(errs, checkForeignKey(r.Name, p, fk, tables, r.Schema.nullChecker())...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks a Table against the schema, foreign keys are not checked as they need other tables.
+func (s *TableSchema) Validate(p *Table) error {
+	return s.validate("", p, s.nullChecker())
+}
+
+func (s *TableSchema) missingValues() []string {
+	if s.MissingValues == nil {
+		return []string{""}
+	}
+	return s.MissingValues
+}
+
+// nullChecker checks values against the missing values of the schema.
+func (s *TableSchema) nullChecker() func(col int, v string) bool {
+	missing := newNullSet(s.missingValues())
+	return func(col int, v string) bool {
+		return missing.has(v)
+	}
+}
+
+// setDecimalColumns marks number and integer fields of the schema as decimal columns of p, so they are sorted
+// by their values. The scale of a number column is the largest scale of its values, fields missing in p are skipped.
+func (s *TableSchema) setDecimalColumns(p *Table) error {
+	isNull := p.nullChecker()
+	for _, f := range s.Fields {
+		c, exists := p.titles[f.Name]
+		if !exists || (f.Type != "number" && f.Type != "integer") {
+			continue
+		}
+		var format DecimalFormat
+		if f.Type == "number" {
+			for _, row := range p.rows {
+				if nullAt(isNull, c, row[c]) {
+					continue
+				}
+				if d, err := ParseDecimal(row[c]); err == nil && d.Scale() > format.Scale {
+					format.Scale = d.Scale()
+				}
+			}
+		}
+		if err := p.SetDecimalColumn(f.Name, format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks p against the schema, isNull decides which values are missing.
+func (s *TableSchema) validate(resource string, p *Table, isNull func(col int, v string) bool) error {
+	var errs []error
+
+	inds := make([]int, len(s.Fields))
+	for i, f := range s.Fields {
+		ind, exists := p.titles[f.Name]
+		if !exists {
+			errs = append(errs, SchemaError{resource, -1, f.Name, TitleNotFound(f.Name).Error()})
+			inds[i] = -1
+			continue
+		}
+		inds[i] = ind
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	inPrimaryKey := make(map[string]bool, len(s.PrimaryKey))
+	for _, n := range s.PrimaryKey {
+		inPrimaryKey[n] = true
+	}
+
+	for i, f := range s.Fields {
+		var seen map[string]int
+		if f.Constraints != nil && f.Constraints.Unique {
+			seen = make(map[string]int)
+		}
+		for r, row := range p.rows {
+			v := row[inds[i]]
+			if isNull(inds[i], v) {
+				if inPrimaryKey[f.Name] || (f.Constraints != nil && f.Constraints.Required) {
+					errs = append(errs, SchemaError{resource, r, f.Name, "a value is required"})
+				}
+				continue
+			}
+			if err := f.check(v); err != nil {
+				errs = append(errs, SchemaError{resource, r, f.Name, err.Error()})
+			}
+			if seen != nil {
+				if first, ok := seen[v]; ok {
+					errs = append(errs, SchemaError{resource, r, f.Name, fmt.Sprintf("%q is not unique, it appears in row %d", v, first)})
+				} else {
+					seen[v] = r
+				}
+			}
+		}
+	}
+
+	if len(s.PrimaryKey) > 0 {
+		keys, err := p.titles.indexes(s.PrimaryKey)
+		if err != nil {
+			errs = append(errs, SchemaError{resource, -1, strings.Join(s.PrimaryKey, ","), err.Error()})
+		} else {
+			seen := make(map[string]int)
+			for r, row := range p.rows {
+				k := rowKey(row, keys)
+				if first, ok := seen[k]; ok {
+					errs = append(errs, SchemaError{resource, r, strings.Join(s.PrimaryKey, ","), fmt.Sprintf("primary key is not unique, it appears in row %d", first)})
+				} else {
+					seen[k] = r
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkForeignKey checks every row of p has its foreign key values in the referenced table.
+// Rows with all foreign key values missing are skipped, isNull decides which values are missing.
+func checkForeignKey(resource string, p *Table, fk ForeignKey, tables map[string]*Table, isNull func(col int, v string) bool) []error {
+	field := strings.Join(fk.Fields, ",")
+	ref := p
+	if fk.Reference.Resource != "" {
+		var ok bool
+		if ref, ok = tables[fk.Reference.Resource]; !ok {
+			return []error{SchemaError{resource, -1, field, fmt.Sprintf("referenced resource %q is missing", fk.Reference.Resource)}}
+		}
+	}
+	if len(fk.Fields) != len(fk.Reference.Fields) {
+		return []error{SchemaError{resource, -1, field, "foreign key and its reference have different numbers of fields"}}
+	}
+	inds, err := p.titles.indexes(fk.Fields)
+	if err != nil {
+		return []error{SchemaError{resource, -1, field, err.Error()}}
+	}
+	refInds, err := ref.titles.indexes(fk.Reference.Fields)
+	if err != nil {
+		return []error{SchemaError{resource, -1, field, err.Error()}}
+	}
+
+	referenced := make(map[string]struct{}, len(ref.rows))
+	for _, row := range ref.rows {
+		referenced[rowKey(row, refInds)] = struct{}{}
+	}
+
+	var errs []error
+	for r, row := range p.rows {
+		allNull := true
+		for _, i := range inds {
+			allNull = allNull && nullAt(isNull, i, row[i])
+		}
+		if allNull {
+			continue
+		}
+		if _, ok := referenced[rowKey(row, inds)]; !ok {
+			errs = append(errs, SchemaError{resource, r, field, fmt.Sprintf("foreign key is not found in resource %q", fk.Reference.Resource)})
+		}
+	}
+	return errs
+}
+
+// check checks a non-missing value against the type and constraints of the field.
+func (f SchemaField) check(v string) error {
+	parsed, err := f.parse(v)
+	if err != nil {
+		return err
+	}
+
+	c := f.Constraints
+	if c == nil {
+		return nil
+	}
+	if c.MinLength != nil && len([]rune(v)) < *c.MinLength {
+		return fmt.Errorf("%q is shorter than %d", v, *c.MinLength)
+	}
+	if c.MaxLength != nil && len([]rune(v)) > *c.MaxLength {
+		return fmt.Errorf("%q is longer than %d", v, *c.MaxLength)
+	}
+	if c.Pattern != "" {
+		pattern, err := regexp.Compile(`^(?:` + c.Pattern + `)$`)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", c.Pattern, err)
+		}
+		if !pattern.MatchString(v) {
+			return fmt.Errorf("%q does not match pattern %q", v, c.Pattern)
+		}
+	}
+	if c.Minimum != nil {
+		bound, err := f.parse(fmt.Sprint(c.Minimum))
+		if err != nil {
+			return fmt.Errorf("invalid minimum: %w", err)
+		}
+		if order, ok := compareParsed(parsed, bound); ok && order < 0 {
+			return fmt.Errorf("%q is less than minimum %v", v, c.Minimum)
+		}
+	}
+	if c.Maximum != nil {
+		bound, err := f.parse(fmt.Sprint(c.Maximum))
+		if err != nil {
+			return fmt.Errorf("invalid maximum: %w", err)
+		}
+		if order, ok := compareParsed(parsed, bound); ok && order > 0 {
+			return fmt.Errorf("%q is greater than maximum %v", v, c.Maximum)
+		}
+	}
+	if len(c.Enum) > 0 {
+		for _, e := range c.Enum {
+			allowed, err := f.parse(fmt.Sprint(e))
+			if err != nil {
+				continue
+			}
+			if order, ok := compareParsed(parsed, allowed); ok && order == 0 {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of %v", v, c.Enum)
+	}
+	return nil
+}
+
+// parse converts a value to float64, bool, time.Time or string according to the type of the field.
+func (f SchemaField) parse(v string) (any, error) {
+	switch f.Type {
+	case "integer":
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", v)
+		}
+		return float64(i), nil
+	case "number":
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", v)
+		}
+		return n, nil
+	case "year":
+		y, err := strconv.Atoi(v)
+		if err != nil || len(v) != 4 {
+			return nil, fmt.Errorf("%q is not a year", v)
+		}
+		return float64(y), nil
+	case "boolean":
+		trues, falses := f.TrueValues, f.FalseValues
+		if trues == nil {
+			trues = []string{"true", "True", "TRUE", "1"}
+		}
+		if falses == nil {
+			falses = []string{"false", "False", "FALSE", "0"}
+		}
+		for _, t := range trues {
+			if v == t {
+				return true, nil
+			}
+		}
+		for _, t := range falses {
+			if v == t {
+				return false, nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not a boolean", v)
+	case "date", "datetime", "time":
+		layout := timeLayout(f.Type, f.Format)
+		if layout == "" {
+			return v, nil
+		}
+		t, err := time.Parse(layout, v)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a %s in format %q", v, f.Type, layout)
+		}
+		return t, nil
+	}
+	return v, nil
+}
+
+// timeLayout converts a format of a temporal field to a layout of time package.
+// An empty layout means values are not checked, it is the case of "any" format.
+func timeLayout(typ, format string) string {
+	switch format {
+	case "", "default":
+		switch typ {
+		case "date":
+			return "2006-01-02"
+		case "time":
+			return "15:04:05"
+		}
+		return time.RFC3339
+	case "any":
+		return ""
+	}
+	replacer := strings.NewReplacer(
+		"%Y", "2006", "%y", "06", "%m", "01", "%d", "02", "%b", "Jan", "%B", "January",
+		"%H", "15", "%I", "03", "%p", "PM", "%M", "04", "%S", "05", "%z", "-0700", "%Z", "MST",
+	)
+	return replacer.Replace(format)
+}
+
+// compareParsed compares two values returned by SchemaField.parse, ok is false when they cannot be compared.
+func compareParsed(a, b any) (order int, ok bool) {
+	switch p := a.(type) {
+	case float64:
+		if q, is := b.(float64); is {
+			return compare(p, q), true
+		}
+	case time.Time:
+		if q, is := b.(time.Time); is {
+			return p.Compare(q), true
+		}
+	case string:
+		if q, is := b.(string); is {
+			return compare(p, q), true
+		}
+	case bool:
+		if q, is := b.(bool); is && p == q {
+			return 0, true
+		}
+		return 1, true
+	}
+	return 0, false
+}
+
+// InferSchema creates a TableSchema from the titles and the values of a Table.
+// Nulls of the Table are skipped and their tokens, set for the Table or for columns, become the missing values
+// of the schema, as a Table Schema cannot have missing values of a single field.
+func InferSchema(p *Table) *TableSchema {
+	names := p.titles.names()
+	isNull := p.nullChecker()
+	candidates := []string{"integer", "number", "boolean", "date", "datetime"}
+
+	s := &TableSchema{Fields: make([]SchemaField, len(names))}
+	for c, n := range names {
+		typ := "string"
+		for _, candidate := range candidates {
+			f := SchemaField{Type: candidate}
+			fits, values := true, 0
+			for _, row := range p.rows {
+				if nullAt(isNull, c, row[c]) || (isNull == nil && row[c] == "") {
+					continue
+				}
+				values++
+				if _, err := f.parse(row[c]); err != nil {
+					fits = false
+					break
+				}
+			}
+			if fits && values > 0 {
+				typ = candidate
+				break
+			}
+		}
+		s.Fields[c] = SchemaField{Name: n, Type: typ}
+	}
+
+	if p.nulls != nil && (p.nulls.table != nil || len(p.nulls.columns) > 0) {
+		missing := make(nullSet)
+		for t := range p.nulls.table {
+			missing[t] = struct{}{}
+		}
+		for _, set := range p.nulls.columns {
+			for t := range set {
+				missing[t] = struct{}{}
+			}
+		}
+		s.MissingValues = missing.tokens()
+	}
+	return s
+}
+
+// WriteDataPackage writes tables as csv files into directory dir, and describes them with inferred
+// schemas in a datapackage.json file. Keys of tables are used as resource names and file names.
+func WriteDataPackage(dir, name string, tables map[string]*Table) error {
+	names := make([]string, 0, len(tables))
+	for n := range tables {
+		if !validResourceName.MatchString(n) {
+			return fmt.Errorf("%q is not a valid resource name, it can only have lower case letters, digits, '.', '_' and '-'", n)
+		}
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	dp := DataPackage{Name: name, Resources: make([]DataResource, 0, len(names))}
+	for _, n := range names {
+		path := n + ".csv"
+		if err := writeFile(filepath.Join(dir, path), tables[n]); err != nil {
+			return fmt.Errorf("resource %q: %w", n, err)
+		}
+		dp.Resources = append(dp.Resources, DataResource{
+			Name:    n,
+			Path:    path,
+			Profile: tabularDataResource,
+			Schema:  InferSchema(tables[n]),
+		})
+	}
+
+	content, err := json.MarshalIndent(dp, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, dataPackageFile), append(content, '\n'), 0o644)
+}
+
+// writeFile creates or truncates a file named by fileName and writes the Table into it.
+func writeFile(fileName string, p *Table) error {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	return errors.Join(p.Write(f), f.Close())
+}
@@ -0,0 +1,225 @@
+package csv
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const packageDescriptor = `{
+  "name": "shop",
+  "resources": [
+    {
+      "name": "customers",
+      "path": "customers.csv",
+      "schema": {
+        "fields": [
+          {"name": "id", "type": "integer"},
+          {"name": "name", "type": "string", "constraints": {"required": true, "maxLength": 10}},
+          {"name": "joined", "type": "date"}
+        ],
+        "primaryKey": "id",
+        "missingValues": ["", "N/A"]
+      }
+    },
+    {
+      "name": "orders",
+      "path": "orders.csv",
+      "schema": {
+        "fields": [
+          {"name": "order", "type": "integer", "constraints": {"unique": true, "minimum": 1}},
+          {"name": "customer", "type": "integer"},
+          {"name": "status", "type": "string", "constraints": {"enum": ["open", "closed"]}}
+        ],
+        "primaryKey": ["order"],
+        "foreignKeys": [{"fields": "customer", "reference": {"resource": "customers", "fields": "id"}}]
+      }
+    }
+  ]
+}`
+
+func writePackage(t *testing.T, customers, orders string) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		dataPackageFile: packageDescriptor,
+		"customers.csv": customers,
+		"orders.csv":    orders,
+	}
+	for n, c := range files {
+		if err := os.WriteFile(filepath.Join(dir, n), []byte(c), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return filepath.Join(dir, dataPackageFile)
+}
+
+// flatten unwraps joined errors into a flat list.
+func flatten(err error) []error {
+	if err == nil {
+		return nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range joined.Unwrap() {
+		errs = append(errs, flatten(e)...)
+	}
+	return errs
+}
+
+func TestReadDataPackage(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		fileName := writePackage(t,
+			"id,name,joined\n1,Rob,2009-11-10\n2,Ken,N/A\n",
+			"order,customer,status\n1,1,open\n2,2,closed\n3,,open\n")
+		dp, tables, err := ReadDataPackage(fileName)
+		if err != nil {
+			t.Fatalf("ReadDataPackage failed: %s", err)
+		}
+		if len(tables) != 2 {
+			t.Fatalf("Want 2 tables, but got %d", len(tables))
+		}
+		if err = dp.Validate(tables); err != nil {
+			t.Errorf("Want a valid package, but got: %s", err)
+		}
+	})
+
+	t.Run("Field types", func(t *testing.T) {
+		dir := t.TempDir()
+		descriptor := `{"resources": [{"name": "prices", "path": "prices.csv", "schema": {"fields": [
+			{"name": "item", "type": "string"}, {"name": "qty", "type": "integer"}, {"name": "price", "type": "number"}
+		], "missingValues": ["", "-"]}}]}`
+		files := map[string]string{dataPackageFile: descriptor, "prices.csv": "item,qty,price\na,3,9.5\nb,20,10.25\nc,1,-\nd,4,1e1\n"}
+		for n, c := range files {
+			if err := os.WriteFile(filepath.Join(dir, n), []byte(c), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		_, tables, err := ReadDataPackage(filepath.Join(dir, dataPackageFile))
+		if err != nil {
+			t.Fatalf("ReadDataPackage failed: %s", err)
+		}
+		p := tables["prices"]
+		want := map[string]DecimalFormat{"qty": {}, "price": {Scale: 2}}
+		if !reflect.DeepEqual(p.decimals, want) {
+			t.Errorf("ReadDataPackage decimal columns = %v, want %v", p.decimals, want)
+		}
+		p.Sort([]Marker{{Index: 2, Order: Ascending}})
+		var items []string
+		for _, row := range p.rows {
+			items = append(items, row[0])
+		}
+		if want := []string{"a", "d", "b", "c"}; !reflect.DeepEqual(items, want) {
+			t.Errorf("Sorted by price items = %v, want %v", items, want)
+		}
+	})
+
+	t.Run("Missing foreign key values", func(t *testing.T) {
+		fileName := writePackage(t,
+			"id,name,joined\n1,Rob,2009-11-10\n",
+			"order,customer,status\n1,1,open\n2,,open\n")
+		dp, tables, err := ReadDataPackage(fileName)
+		if err != nil {
+			t.Fatalf("ReadDataPackage failed: %s", err)
+		}
+		// missing values come from the schema, not from nulls of the table
+		tables["orders"].SetNulls(nil)
+		if err = dp.Validate(tables); err != nil {
+			t.Errorf("Want a valid package, but got: %s", err)
+		}
+	})
+
+	t.Run("Paths", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "package")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "..", "secret.csv"), []byte("a\n1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		for _, path := range []string{"../secret.csv", "data/../../secret.csv", "/etc/passwd", ""} {
+			descriptor := `{"resources": [{"name": "secret", "path": "` + path + `"}]}`
+			if err := os.WriteFile(filepath.Join(dir, dataPackageFile), []byte(descriptor), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := ReadDataPackage(filepath.Join(dir, dataPackageFile)); err == nil {
+				t.Errorf("ReadDataPackage should fail on path %q", path)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		fileName := writePackage(t,
+			"id,name,joined\n1,Rob,10/11/2009\n1,,2009-11-10\n",
+			"order,customer,status\n0,1,open\n2,3,pending\n2,1,open\n")
+		dp, tables, err := ReadDataPackage(fileName)
+		if err != nil {
+			t.Fatalf("ReadDataPackage failed: %s", err)
+		}
+		err = dp.Validate(tables)
+		// date, required, primary key, minimum, enum, foreign key, unique, primary key
+		want := 8
+		if got := len(flatten(err)); got != want {
+			t.Errorf("Want %d schema errors, but got %d: %s", want, got, err)
+		}
+	})
+}
+
+func TestWriteDataPackage(t *testing.T) {
+	dir := t.TempDir()
+	p := &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
+	p.rows[0][2] = "-"
+	p.SetNulls([]string{"-"})
+
+	if err := WriteDataPackage(dir, "scores", map[string]*Table{"Scores": p}); err == nil {
+		t.Error("Upper case resource name should be rejected")
+	}
+	if err := WriteDataPackage(dir, "scores", map[string]*Table{"scores": p}); err != nil {
+		t.Fatalf("WriteDataPackage failed: %s", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, dataPackageFile))
+	var dp DataPackage
+	if err := json.Unmarshal(content, &dp); err != nil {
+		t.Fatalf("Invalid descriptor: %s", err)
+	}
+	fields := dp.Resources[0].Schema.Fields
+	if fields[0].Type != "string" || fields[2].Type != "integer" {
+		t.Errorf("Want inferred types string and integer, but got %s and %s", fields[0].Type, fields[2].Type)
+	}
+
+	read, tables, err := ReadDataPackage(filepath.Join(dir, dataPackageFile))
+	if err != nil {
+		t.Fatalf("ReadDataPackage failed: %s", err)
+	}
+	if err = read.Validate(tables); err != nil {
+		t.Errorf("Written package should be valid, but got: %s", err)
+	}
+
+	t.Run("Column nulls", func(t *testing.T) {
+		dir := t.TempDir()
+		p := &Table{titles: createTitle([]string{"user", "score"}), rows: [][]string{{"rob", "100"}, {"ken", "x"}, {"-", "80"}}}
+		if err := p.SetColumnNulls("score", []string{"x"}); err != nil {
+			t.Fatal(err)
+		}
+		p.SetNulls([]string{"-"})
+		if err := WriteDataPackage(dir, "scores", map[string]*Table{"scores": p}); err != nil {
+			t.Fatalf("WriteDataPackage failed: %s", err)
+		}
+		read, tables, err := ReadDataPackage(filepath.Join(dir, dataPackageFile))
+		if err != nil {
+			t.Fatalf("ReadDataPackage failed: %s", err)
+		}
+		schema := read.Resources[0].Schema
+		if schema.Fields[1].Type != "integer" || !reflect.DeepEqual(schema.MissingValues, []string{"-", "x"}) {
+			t.Errorf("Want integer score with missing values of the column, but got %s and %q", schema.Fields[1].Type, schema.MissingValues)
+		}
+		if err = read.Validate(tables); err != nil {
+			t.Errorf("Written package should be valid, but got: %s", err)
+		}
+	})
+}
@@ -0,0 +1,329 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode defines how to round a Decimal when its scale is reduced.
+type RoundingMode int
+
+const (
+	// HalfEven rounds to the nearest neighbour, ties go to the even neighbour. It is also known as banker's rounding.
+	HalfEven = RoundingMode(iota)
+	// HalfUp rounds to the nearest neighbour, ties go away from zero.
+	HalfUp
+	// Down truncates towards zero.
+	Down
+)
+
+// Decimal is an exact decimal number: unscaled * 10^-scale. The zero value is 0.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// DecimalFormat defines the scale and the rounding mode of a decimal column.
+type DecimalFormat struct {
+	Scale    int
+	Rounding RoundingMode
+}
+
+// maxDecimalExponent bounds exponents accepted by ParseDecimal, as a value like 1e9999999 would need
+// millions of digits.
+const maxDecimalExponent = 1000
+
+// ParseDecimal converts a string like "-12.345" or "1.5e3" to a Decimal without losing any digit.
+// Leading and trailing spaces are ignored. Exponents beyond ±1000 are rejected.
+func ParseDecimal(s string) (Decimal, error) {
+	v := strings.TrimSpace(s)
+	exp := 0
+	if i := strings.IndexAny(v, "eE"); i >= 0 {
+		e, err := strconv.Atoi(v[i+1:])
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("%q is not a decimal", s)
+		}
+		exp = e
+		v = v[:i]
+	}
+
+	neg := false
+	if v != "" && (v[0] == '-' || v[0] == '+') {
+		neg = v[0] == '-'
+		v = v[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(v, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return Decimal{}, fmt.Errorf("%q is not a decimal", s)
+	}
+
+	u, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		u.Neg(u)
+	}
+	d := Decimal{u, len(fracPart) - exp}
+	if d.scale < 0 {
+		return d.Round(0, Down), nil
+	}
+	return d, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s cannot be parsed.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// pow10 returns 10^n, n should not be negative.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// align returns unscaled values of d and e at the same scale.
+func (d Decimal) align(e Decimal) (*big.Int, *big.Int, int) {
+	p, q := d.int(), e.int()
+	switch {
+	case d.scale < e.scale:
+		return new(big.Int).Mul(p, pow10(e.scale-d.scale)), q, e.scale
+	case d.scale > e.scale:
+		return p, new(big.Int).Mul(q, pow10(d.scale-e.scale)), d.scale
+	}
+	return p, q, d.scale
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Sign returns -1, 0 or 1 when d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// Cmp returns -1, 0 or 1 when d is less than, equal to or greater than e.
+func (d Decimal) Cmp(e Decimal) int {
+	p, q, _ := d.align(e)
+	return p.Cmp(q)
+}
+
+// Add returns d + e, the scale is the larger one of both.
+func (d Decimal) Add(e Decimal) Decimal {
+	p, q, s := d.align(e)
+	return Decimal{new(big.Int).Add(p, q), s}
+}
+
+// Sub returns d - e, the scale is the larger one of both.
+func (d Decimal) Sub(e Decimal) Decimal {
+	p, q, s := d.align(e)
+	return Decimal{new(big.Int).Sub(p, q), s}
+}
+
+// Mul returns d * e, the scale is the sum of both.
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{new(big.Int).Mul(d.int(), e.int()), d.scale + e.scale}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{new(big.Int).Neg(d.int()), d.scale}
+}
+
+// Quo returns d / e rounded to scale with the rounding mode. It returns an error when e is zero.
+func (d Decimal) Quo(e Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if e.Sign() == 0 {
+		return Decimal{}, errors.New("division by zero")
+	}
+	// d.u * 10^-d.s / (e.u * 10^-e.s) = d.u * 10^(scale + e.s - d.s) / e.u * 10^-scale
+	num, den := new(big.Int).Set(d.int()), new(big.Int).Set(e.int())
+	if shift := scale + e.scale - d.scale; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	return Decimal{roundQuo(num, den, mode), scale}, nil
+}
+
+// Round returns d with the scale, digits are rounded with the rounding mode when the scale is reduced.
+func (d Decimal) Round(scale int, mode RoundingMode) Decimal {
+	if scale >= d.scale {
+		return Decimal{new(big.Int).Mul(d.int(), pow10(scale-d.scale)), scale}
+	}
+	return Decimal{roundQuo(d.int(), pow10(d.scale-scale), mode), scale}
+}
+
+// roundQuo returns num / den rounded with the rounding mode.
+func roundQuo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 || mode == Down {
+		return q
+	}
+
+	// compare the remainder with half of the divisor
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	order := half.Cmp(new(big.Int).Abs(den))
+	if order > 0 || (order == 0 && (mode == HalfUp || q.Bit(0) == 1)) {
+		if num.Sign()*den.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// String formats d with all its digits after the decimal point and without an exponent.
+func (d Decimal) String() string {
+	u := d.int()
+	if d.scale <= 0 {
+		return new(big.Int).Mul(u, pow10(-d.scale)).String()
+	}
+
+	digits := new(big.Int).Abs(u).String()
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	point := len(digits) - d.scale
+	sign := ""
+	if u.Sign() < 0 {
+		sign = "-"
+	}
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Format rounds d to the scale of the format and converts it to a string.
+func (f DecimalFormat) Format(d Decimal) string {
+	return f.Round(d).String()
+}
+
+// Round rounds d to the scale of the format.
+func (f DecimalFormat) Round(d Decimal) Decimal {
+	return d.Round(f.Scale, f.Rounding)
+}
+
+// SetDecimalColumn marks the named column as a decimal column, it is sorted by exact decimal values.
+func (p *Table) SetDecimalColumn(name string, f DecimalFormat) error {
+	if _, exists := p.titles[name]; !exists {
+		return fmt.Errorf("failed to execute SetDecimalColumn method: %w", TitleNotFound(name))
+	}
+	if p.decimals == nil {
+		p.decimals = make(map[string]DecimalFormat)
+	}
+	p.decimals[name] = f
+	p.changed()
+	return nil
+}
+
+func cloneDecimals(decimals map[string]DecimalFormat) map[string]DecimalFormat {
+	if decimals == nil {
+		return nil
+	}
+	c := make(map[string]DecimalFormat, len(decimals))
+	for k, v := range decimals {
+		c[k] = v
+	}
+	return c
+}
+
+// decimalColumns returns indexes of decimal columns.
+func (p *Table) decimalColumns() map[int]struct{} {
+	if len(p.decimals) == 0 {
+		return nil
+	}
+	inds := make(map[int]struct{}, len(p.decimals))
+	for name := range p.decimals {
+		if ind, exists := p.titles[name]; exists {
+			inds[ind] = struct{}{}
+		}
+	}
+	return inds
+}
+
+// decimalsAt parses the named column, nulls are skipped and all unparsable values are reported in the error.
+// Rows of nulls are not in the returned map.
+func (p *Table) decimalsAt(name string) (map[int]Decimal, error) {
+	ind, exists := p.titles[name]
+	if !exists {
+		return nil, TitleNotFound(name)
+	}
+	isNull := p.nullChecker()
+	values := make(map[int]Decimal, len(p.rows))
+	var errs []error
+	for r, row := range p.rows {
+		if nullAt(isNull, ind, row[ind]) {
+			continue
+		}
+		d, err := ParseDecimal(row[ind])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("row %d: %w", r, err))
+			continue
+		}
+		values[r] = d
+	}
+	return values, errors.Join(errs...)
+}
+
+// SumDecimal returns the exact sum of the named column. Nulls are skipped, unparsable values are errors.
+func (p *Table) SumDecimal(name string) (Decimal, error) {
+	values, err := p.decimalsAt(name)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("failed to execute SumDecimal method: %w", err)
+	}
+	var total Decimal
+	for _, d := range values {
+		total = total.Add(d)
+	}
+	return total, nil
+}
+
+// DeriveDecimal is the decimal version of Derive: it uses two named columns to derive a new column formatted by f.
+// Rows which have a null in any of the two columns get an empty string. If any value cannot be parsed, no column is added.
+func (p *Table) DeriveDecimal(nameA, nameB, name string, f DecimalFormat, op func(a, b Decimal) (Decimal, error)) error {
+	if _, exists := p.titles[name]; exists {
+		return fmt.Errorf("failed to execute DeriveDecimal method: %s already exists", name)
+	}
+	as, errA := p.decimalsAt(nameA)
+	bs, errB := p.decimalsAt(nameB)
+	if err := errors.Join(errA, errB); err != nil {
+		return fmt.Errorf("failed to execute DeriveDecimal method: %w", err)
+	}
+
+	derived := make([]string, len(p.rows))
+	for r := range p.rows {
+		a, okA := as[r]
+		b, okB := bs[r]
+		if !okA || !okB {
+			continue
+		}
+		d, err := op(a, b)
+		if err != nil {
+			return fmt.Errorf("failed to execute DeriveDecimal method: row %d: %w", r, err)
+		}
+		derived[r] = f.Format(d)
+	}
+
+	p.titles[name] = len(p.titles)
+	for r := range p.rows {
+		p.rows[r] = append(p.rows[r], derived[r])
+	}
+	if p.decimals == nil {
+		p.decimals = make(map[string]DecimalFormat)
+	}
+	p.decimals[name] = f
+	return nil
+}
@@ -0,0 +1,138 @@
+package csv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"0.1", "0.1"},
+		{" -12.345 ", "-12.345"},
+		{"+.5", "0.5"},
+		{"7.", "7"},
+		{"1.5e3", "1500"},
+		{"-25e-4", "-0.0025"},
+		{"1e1000", "1" + strings.Repeat("0", 1000)},
+		{"123456789012345678901234567890.01", "123456789012345678901234567890.01"},
+	}
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		if err != nil || d.String() != tt.want {
+			t.Errorf("ParseDecimal(%q) = %s, %v, want %s", tt.in, d, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", ".", "1,5", "1.2.3", "e3", "NaN", "1e1001", "1e-1001", "1e9999999", "1e-9999999"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("ParseDecimal(%q) should fail", in)
+		}
+	}
+}
+
+func TestDecimal_Round(t *testing.T) {
+	tests := []struct {
+		in   string
+		mode RoundingMode
+		want string
+	}{
+		{"2.345", HalfEven, "2.34"},
+		{"2.355", HalfEven, "2.36"},
+		{"2.345", HalfUp, "2.35"},
+		{"-2.345", HalfUp, "-2.35"},
+		{"-2.345", HalfEven, "-2.34"},
+		{"2.349", Down, "2.34"},
+		{"2.3461", HalfEven, "2.35"},
+		{"2", HalfEven, "2.00"},
+	}
+	for _, tt := range tests {
+		if got := MustParseDecimal(tt.in).Round(2, tt.mode).String(); got != tt.want {
+			t.Errorf("Round(%s, 2, %d) = %s, want %s", tt.in, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestDecimal_arithmetic(t *testing.T) {
+	a, b := MustParseDecimal("0.1"), MustParseDecimal("0.2")
+	if got := a.Add(b); got.Cmp(MustParseDecimal("0.3")) != 0 {
+		t.Errorf("0.1 + 0.2 = %s, want 0.3", got)
+	}
+	if got := a.Sub(b).String(); got != "-0.1" {
+		t.Errorf("0.1 - 0.2 = %s, want -0.1", got)
+	}
+	if got := MustParseDecimal("19.99").Mul(MustParseDecimal("3")).String(); got != "59.97" {
+		t.Errorf("19.99 * 3 = %s, want 59.97", got)
+	}
+	if got, _ := MustParseDecimal("10").Quo(MustParseDecimal("3"), 4, HalfEven); got.String() != "3.3333" {
+		t.Errorf("10 / 3 = %s, want 3.3333", got)
+	}
+	if got, _ := MustParseDecimal("-1").Quo(MustParseDecimal("8"), 2, HalfUp); got.String() != "-0.13" {
+		t.Errorf("-1 / 8 = %s, want -0.13", got)
+	}
+	if _, err := a.Quo(Decimal{}, 2, HalfEven); err == nil {
+		t.Error("Division by zero should fail")
+	}
+}
+
+func prices() *Table {
+	return &Table{
+		titles: createTitle([]string{"item", "price", "quantity"}),
+		rows: [][]string{
+			{"pen", "0.10", "3"},
+			{"book", "19.99", "2"},
+			{"bag", "", "1"},
+			{"ink", "0.20", "7"},
+			{"cap", "9.5", "1"},
+		},
+	}
+}
+
+func TestTable_DeriveDecimal(t *testing.T) {
+	p := prices()
+	p.SetNulls([]string{""})
+
+	sum, err := p.SumDecimal("price")
+	if err != nil || sum.String() != "29.79" {
+		t.Errorf("SumDecimal = %s, %v, want 29.79", sum, err)
+	}
+
+	mul := func(a, b Decimal) (Decimal, error) { return a.Mul(b), nil }
+	if err = p.DeriveDecimal("price", "quantity", "total", DecimalFormat{Scale: 2}, mul); err != nil {
+		t.Fatalf("DeriveDecimal failed: %s", err)
+	}
+	want := []string{"0.30", "39.98", "", "1.40", "9.50"}
+	for i, row := range p.rows {
+		if row[3] != want[i] {
+			t.Errorf("Row %d: total = %s, want %s", i, row[3], want[i])
+		}
+	}
+
+	p.rows[0][1] = "ten cents"
+	if err = p.DeriveDecimal("price", "quantity", "again", DecimalFormat{Scale: 2}, mul); err == nil {
+		t.Error("DeriveDecimal should fail on unparsable values")
+	}
+	if _, err = p.SumDecimal("price"); err == nil {
+		t.Error("SumDecimal should fail on unparsable values")
+	}
+}
+
+func TestTable_Sort_decimal(t *testing.T) {
+	p := prices()
+	p.SetNulls([]string{""})
+	if err := p.SetDecimalColumn("price", DecimalFormat{Scale: 2}); err != nil {
+		t.Fatal(err)
+	}
+	p.Sort([]Marker{{Index: 1, Order: Descending}})
+
+	got := make([]string, len(p.rows))
+	for i, r := range p.rows {
+		got[i] = r[0]
+	}
+	want := []string{"book", "cap", "ink", "pen", "bag"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Sort by decimal = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+package csv
+
+import (
+	"fmt"
+	"sort"
+)
+
+// KeepPolicy defines which row of duplicates Deduplicate keeps.
+type KeepPolicy int
+
+const (
+	// KeepFirst keeps the first row of duplicates.
+	KeepFirst = KeepPolicy(iota)
+	// KeepLast keeps the last row of duplicates.
+	KeepLast
+	// KeepMax keeps the row with the largest value of DedupOptions.MaxColumn, the first one of them when there are ties.
+	KeepMax
+)
+
+// DedupOptions configures Deduplicate. MaxColumn is needed by KeepMax, it is compared in the same way as Sort does.
+type DedupOptions struct {
+	Keep      KeepPolicy
+	MaxColumn string
+}
+
+// Duplicate is a row removed by Deduplicate. Row is its original position and Kept is the original position of
+// the row kept instead of it.
+type Duplicate struct {
+	Row    int
+	Kept   int
+	Values []string
+}
+
+// Deduplicate removes rows with the same values of the named columns, all columns when names is empty, and keeps
+// one row of each key by the policy of opts. Kept rows stay in their original order. Removed rows are returned
+// ordered by their original positions.
+func (p *Table) Deduplicate(names []string, opts DedupOptions) ([]Duplicate, error) {
+	var inds []int
+	if len(names) == 0 {
+		inds = seq(len(p.titles))
+	} else {
+		var err error
+		if inds, err = p.titles.indexes(names); err != nil {
+			return nil, fmt.Errorf("failed to execute Deduplicate method: %w", err)
+		}
+	}
+
+	var better func(a, b []string) bool
+	switch opts.Keep {
+	case KeepFirst:
+		better = func(a, b []string) bool { return false }
+	case KeepLast:
+		better = func(a, b []string) bool { return true }
+	case KeepMax:
+		markers, err := p.titles.sortingMarkers([]NamedMarker{{Name: opts.MaxColumn, Order: Descending}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute Deduplicate method: %w", err)
+		}
+		cmp := p.rowComparer(markers)
+		better = func(a, b []string) bool { return cmp(a, b) < 0 }
+	default:
+		return nil, fmt.Errorf("failed to execute Deduplicate method: unknown keep policy %d", opts.Keep)
+	}
+
+	kept := make(map[string]int)
+	var order []string
+	for r, row := range p.rows {
+		k := rowKey(row, inds)
+		current, seen := kept[k]
+		if !seen {
+			order = append(order, k)
+		}
+		if !seen || better(row, p.rows[current]) {
+			kept[k] = r
+		}
+	}
+
+	keep := make([]int, 0, len(order))
+	for _, k := range order {
+		keep = append(keep, kept[k])
+	}
+	sort.Ints(keep)
+
+	var removed []Duplicate
+	rows := make([][]string, 0, len(keep))
+	next := 0
+	for r, row := range p.rows {
+		if next < len(keep) && keep[next] == r {
+			rows = append(rows, row)
+			next++
+			continue
+		}
+		removed = append(removed, Duplicate{Row: r, Kept: kept[rowKey(row, inds)], Values: row})
+	}
+	p.rows = rows
+	p.changed()
+	return removed, nil
+}
@@ -0,0 +1,93 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_Deduplicate(t *testing.T) {
+	contacts := func() *Table {
+		return &Table{
+			titles: createTitle([]string{"first", "last", "visits"}),
+			rows: [][]string{
+				{"ab", "c", "3"},
+				{"a", "bc", "1"},
+				{"ab", "c", "12"},
+				{"x", "y", "5"},
+				{"ab", "c", "7"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		opts        DedupOptions
+		want        [][]string
+		wantRemoved []Duplicate
+	}{
+		{
+			name: "Keep first",
+			opts: DedupOptions{},
+			want: [][]string{{"ab", "c", "3"}, {"a", "bc", "1"}, {"x", "y", "5"}},
+			wantRemoved: []Duplicate{
+				{Row: 2, Kept: 0, Values: []string{"ab", "c", "12"}},
+				{Row: 4, Kept: 0, Values: []string{"ab", "c", "7"}},
+			},
+		},
+		{
+			name: "Keep last",
+			opts: DedupOptions{Keep: KeepLast},
+			want: [][]string{{"a", "bc", "1"}, {"x", "y", "5"}, {"ab", "c", "7"}},
+			wantRemoved: []Duplicate{
+				{Row: 0, Kept: 4, Values: []string{"ab", "c", "3"}},
+				{Row: 2, Kept: 4, Values: []string{"ab", "c", "12"}},
+			},
+		},
+		{
+			name: "Keep max",
+			opts: DedupOptions{Keep: KeepMax, MaxColumn: "visits"},
+			want: [][]string{{"a", "bc", "1"}, {"ab", "c", "12"}, {"x", "y", "5"}},
+			wantRemoved: []Duplicate{
+				{Row: 0, Kept: 2, Values: []string{"ab", "c", "3"}},
+				{Row: 4, Kept: 2, Values: []string{"ab", "c", "7"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := contacts()
+			p.SetDecimalColumn("visits", DecimalFormat{})
+			removed, err := p.Deduplicate([]string{"first", "last"}, tt.opts)
+			if err != nil {
+				t.Fatalf("Deduplicate failed: %s", err)
+			}
+			if !reflect.DeepEqual(p.rows, tt.want) {
+				t.Errorf("Deduplicate rows = %v, want %v", p.rows, tt.want)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("Deduplicate removed = %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+
+	t.Run("All columns", func(t *testing.T) {
+		p := contacts()
+		p.rows = append(p.rows, []string{"x", "y", "5"})
+		removed, err := p.Deduplicate(nil, DedupOptions{})
+		if err != nil {
+			t.Fatalf("Deduplicate failed: %s", err)
+		}
+		if len(p.rows) != 5 || len(removed) != 1 || removed[0].Kept != 3 {
+			t.Errorf("Deduplicate should remove only the whole row duplicate, but removed %v", removed)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, err := contacts().Deduplicate([]string{"missing"}, DedupOptions{}); err == nil {
+			t.Error("Deduplicate should fail on a missing column")
+		}
+		if _, err := contacts().Deduplicate(nil, DedupOptions{Keep: KeepMax, MaxColumn: "missing"}); err == nil {
+			t.Error("Deduplicate should fail on a missing max column")
+		}
+	})
+}
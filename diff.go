@@ -0,0 +1,171 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CellChange is a changed value of a column.
+type CellChange struct {
+	Column string
+	Old    string
+	New    string
+}
+
+// RowDiff is a row which is added, removed or modified. OldRow and NewRow are its positions in the old and new
+// Tables, -1 when it does not exist in one of them. Values are the cells of an added or removed row and Changes
+// are the changed cells of a modified row.
+type RowDiff struct {
+	Key     []string
+	OldRow  int
+	NewRow  int
+	Values  []string
+	Changes []CellChange
+}
+
+// TableDiff is the result of Diff. ColumnsReordered reports if columns found in both Tables are in different orders.
+type TableDiff struct {
+	KeyColumns       []string
+	AddedColumns     []string
+	RemovedColumns   []string
+	ColumnsReordered bool
+	Added            []RowDiff
+	Removed          []RowDiff
+	Modified         []RowDiff
+}
+
+// Diff compares two versions of a Table by the named key columns, which should be unique in both of them.
+// Modified rows are found by comparing values of columns found in both Tables. Removed rows are in the order
+// of the old Table, added and modified rows are in the order of the new Table.
+func Diff(old, new *Table, keys []string) (*TableDiff, error) {
+	oldKeys, err := old.titles.indexes(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Diff function: old table: %w", err)
+	}
+	newKeys, err := new.titles.indexes(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Diff function: new table: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("failed to execute Diff function: no key column")
+	}
+
+	d := &TableDiff{KeyColumns: keys}
+	oldNames, newNames := old.titles.names(), new.titles.names()
+	var common, oldOrder []string
+	for _, n := range newNames {
+		if _, ok := old.titles[n]; ok {
+			common = append(common, n)
+		} else {
+			d.AddedColumns = append(d.AddedColumns, n)
+		}
+	}
+	for _, n := range oldNames {
+		if _, ok := new.titles[n]; ok {
+			oldOrder = append(oldOrder, n)
+		} else {
+			d.RemovedColumns = append(d.RemovedColumns, n)
+		}
+	}
+	for i := range common {
+		if common[i] != oldOrder[i] {
+			d.ColumnsReordered = true
+			break
+		}
+	}
+
+	oldIndex, err := uniqueKeys(old.rows, oldKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Diff function: old table: %w", err)
+	}
+	if _, err = uniqueKeys(new.rows, newKeys); err != nil {
+		return nil, fmt.Errorf("failed to execute Diff function: new table: %w", err)
+	}
+
+	matched := make(map[int]bool, len(new.rows))
+	for r, row := range new.rows {
+		o, ok := oldIndex[rowKey(row, newKeys)]
+		if !ok {
+			d.Added = append(d.Added, RowDiff{Key: cellsAt(row, newKeys), OldRow: -1, NewRow: r, Values: row})
+			continue
+		}
+		matched[o] = true
+		var changes []CellChange
+		for _, n := range common {
+			if v, w := old.rows[o][old.titles[n]], row[new.titles[n]]; v != w {
+				changes = append(changes, CellChange{Column: n, Old: v, New: w})
+			}
+		}
+		if changes != nil {
+			d.Modified = append(d.Modified, RowDiff{Key: cellsAt(row, newKeys), OldRow: o, NewRow: r, Changes: changes})
+		}
+	}
+	for r, row := range old.rows {
+		if !matched[r] {
+			d.Removed = append(d.Removed, RowDiff{Key: cellsAt(row, oldKeys), OldRow: r, NewRow: -1, Values: row})
+		}
+	}
+	return d, nil
+}
+
+// uniqueKeys indexes rows by the key of inds, a duplicated key returns an error.
+func uniqueKeys(rows [][]string, inds []int) (map[string]int, error) {
+	index := make(map[string]int, len(rows))
+	for r, row := range rows {
+		k := rowKey(row, inds)
+		if first, ok := index[k]; ok {
+			return nil, fmt.Errorf("rows %d and %d have the same key %s", first, r, strings.Join(cellsAt(row, inds), ", "))
+		}
+		index[k] = r
+	}
+	return index, nil
+}
+
+// HasChanges reports if d has any change of rows or columns.
+func (d *TableDiff) HasChanges() bool {
+	return len(d.AddedColumns)+len(d.RemovedColumns)+len(d.Added)+len(d.Removed)+len(d.Modified) > 0 || d.ColumnsReordered
+}
+
+// Report creates a change report Table with columns change, key columns, column, old and new. Column changes come
+// first, followed by a row for each added and removed row and a row for each changed cell of modified rows.
+// Changes are column_added, column_removed, columns_reordered, added, removed and modified.
+func (d *TableDiff) Report() *Table {
+	titles := append([]string{"change"}, d.KeyColumns...)
+	titles = append(titles, "column", "old", "new")
+	var rows [][]string
+	line := func(change string, key []string, column, old, new string) {
+		row := append([]string{change}, key...)
+		if key == nil {
+			row = append(row, make([]string, len(d.KeyColumns))...)
+		}
+		rows = append(rows, append(row, column, old, new))
+	}
+
+	for _, n := range d.AddedColumns {
+		line("column_added", nil, n, "", "")
+	}
+	for _, n := range d.RemovedColumns {
+		line("column_removed", nil, n, "", "")
+	}
+	if d.ColumnsReordered {
+		line("columns_reordered", nil, "", "", "")
+	}
+	for _, r := range d.Added {
+		line("added", r.Key, "", "", "")
+	}
+	for _, r := range d.Removed {
+		line("removed", r.Key, "", "", "")
+	}
+	for _, r := range d.Modified {
+		for _, c := range r.Changes {
+			line("modified", r.Key, c.Column, c.Old, c.New)
+		}
+	}
+	return &Table{titles: createTitle(titles), rows: rows}
+}
+
+// WriteReport writes the change report of d in CSV format to w.
+func (d *TableDiff) WriteReport(w io.Writer) error {
+	return d.Report().Write(w)
+}
@@ -0,0 +1,74 @@
+package csv
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	yesterday := &Table{
+		titles: createTitle([]string{"id", "name", "price", "stock"}),
+		rows: [][]string{
+			{"1", "pen", "1.20", "10"},
+			{"2", "ink", "3.00", "4"},
+			{"3", "pad", "2.50", "7"},
+		},
+	}
+	today := &Table{
+		titles: createTitle([]string{"id", "price", "name", "colour"}),
+		rows: [][]string{
+			{"3", "2.75", "pad", "white"},
+			{"1", "1.20", "pen", "blue"},
+			{"4", "0.80", "clip", "grey"},
+		},
+	}
+
+	d, err := Diff(yesterday, today, []string{"id"})
+	if err != nil {
+		t.Fatalf("Diff failed: %s", err)
+	}
+	if !reflect.DeepEqual(d.AddedColumns, []string{"colour"}) || !reflect.DeepEqual(d.RemovedColumns, []string{"stock"}) || !d.ColumnsReordered {
+		t.Errorf("Diff columns = %v, %v, %v", d.AddedColumns, d.RemovedColumns, d.ColumnsReordered)
+	}
+	if want := []RowDiff{{Key: []string{"4"}, OldRow: -1, NewRow: 2, Values: []string{"4", "0.80", "clip", "grey"}}}; !reflect.DeepEqual(d.Added, want) {
+		t.Errorf("Diff added = %v, want %v", d.Added, want)
+	}
+	if want := []RowDiff{{Key: []string{"2"}, OldRow: 1, NewRow: -1, Values: []string{"2", "ink", "3.00", "4"}}}; !reflect.DeepEqual(d.Removed, want) {
+		t.Errorf("Diff removed = %v, want %v", d.Removed, want)
+	}
+	want := []RowDiff{{Key: []string{"3"}, OldRow: 2, NewRow: 0, Changes: []CellChange{{Column: "price", Old: "2.50", New: "2.75"}}}}
+	if !reflect.DeepEqual(d.Modified, want) {
+		t.Errorf("Diff modified = %v, want %v", d.Modified, want)
+	}
+	if !d.HasChanges() {
+		t.Error("HasChanges should be true")
+	}
+
+	var buf bytes.Buffer
+	if err = d.WriteReport(&buf); err != nil {
+		t.Fatalf("WriteReport failed: %s", err)
+	}
+	report := "change,id,column,old,new\n" +
+		"column_added,,colour,,\n" +
+		"column_removed,,stock,,\n" +
+		"columns_reordered,,,,\n" +
+		"added,4,,,\n" +
+		"removed,2,,,\n" +
+		"modified,3,price,2.50,2.75\n"
+	if buf.String() != report {
+		t.Errorf("WriteReport =\n%s\nwant\n%s", buf.String(), report)
+	}
+
+	if d, err = Diff(yesterday, yesterday, []string{"id"}); err != nil || d.HasChanges() {
+		t.Errorf("Diff of the same table should have no change, but got %v, %v", d, err)
+	}
+
+	today.rows[1][0] = "3"
+	if _, err = Diff(yesterday, today, []string{"id"}); err == nil {
+		t.Error("Diff should fail on duplicated keys")
+	}
+	if _, err = Diff(yesterday, today, []string{"stock"}); err == nil {
+		t.Error("Diff should fail on a key column missing in the new table")
+	}
+}
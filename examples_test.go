@@ -6,7 +6,7 @@ import (
 )
 
 func ExampleTable_Swap() {
-	p := &Table{createTitle([]string{"user", "sub", "scores"}), numbersAsStrings()}
+	p := &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
 	p.Swap("sub", "user")
 	p.Print()
 
@@ -26,9 +26,9 @@ func ExampleTable_Swap() {
 }
 
 func ExampleTable_Sort_ascending() {
-	markers := []Marker{{0, Ascending}, {2, Ascending}}
+	markers := []Marker{{Index: 0, Order: Ascending}, {Index: 2, Order: Ascending}}
 
-	p := &Table{createTitle([]string{"user", "sub", "scores"}), numbersAsStrings()}
+	p := &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
 	p.Sort(markers)
 	p.Print()
 
@@ -49,9 +49,9 @@ func ExampleTable_Sort_ascending() {
 
 func ExampleTable_Sort_mixed() {
 	titles := createTitle([]string{"user", "sub", "scores"})
-	nms := []NamedMarker{{"user", Ascending}, {"scores", Descending}}
+	nms := []NamedMarker{{Name: "user", Order: Ascending}, {Name: "scores", Order: Descending}}
 
-	p := &Table{titles, numbersAsStrings()}
+	p := &Table{titles: titles, rows: numbersAsStrings()}
 	markers, err := titles.sortingMarkers(nms)
 	if err == nil {
 		p.Sort(markers)
@@ -74,7 +74,7 @@ func ExampleTable_Sort_mixed() {
 }
 
 func ExampleTable_Extract() {
-	p := &Table{createTitle([]string{"user", "sub", "scores"}), numbersAsStrings()}
+	p := &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
 	sub, _ := p.Extract([]string{"sub", "user"})
 
 	for i, row := range sub {
@@ -94,12 +94,12 @@ func ExampleTable_Extract() {
 }
 
 func ExampleTable_Convert() {
-	p := &Table{createTitle([]string{"user", "sub", "scores"}), numbersAsStrings()}
+	p := &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
 	c, err := p.Convert([]string{"scores", "user"})
 
 	if err == nil {
 		// mapping new titles into a slice of sorting markers
-		markers := []Marker{{0, Descending}, {1, Ascending}}
+		markers := []Marker{{Index: 0, Order: Descending}, {Index: 1, Order: Ascending}}
 		c.Sort(markers)
 		c.Print()
 	}
@@ -122,11 +122,11 @@ func ExampleTable_Convert() {
 // ExampleTable_split shows how to split a sorted dataset
 func ExampleTable_Split() {
 	titles := createTitle([]string{"user", "sub", "scores"})
-	p := &Table{titles, numbersAsStrings()}
+	p := &Table{titles: titles, rows: numbersAsStrings()}
 
 	names := []string{"sub"}
 	inds, _ := titles.indexes(names)
-	markers := []Marker{{inds[0], Descending}}
+	markers := []Marker{{Index: inds[0], Order: Descending}}
 	p.Sort(markers)
 	fmt.Println("Source:")
 	p.Print()
@@ -195,7 +195,7 @@ func ExampleTable_Unique() {
 // Examples for rows
 func Example_sortRows() {
 	rows := numbersAsStrings()
-	markers := []Marker{{0, Ascending}, {2, Ascending}}
+	markers := []Marker{{Index: 0, Order: Ascending}, {Index: 2, Order: Ascending}}
 	sorter := OrderByColumns(markers)
 	sorter.Sort(rows)
 
@@ -215,7 +215,7 @@ func Example_sortDates() {
 1/1/2005
 `
 	records, _ := read(strings.NewReader(dates))
-	p := &Table{createTitle(records[0][:]), records[1:][:]}
+	p := &Table{titles: createTitle(records[0][:]), rows: records[1:][:]}
 
 	pad := func(d string) string {
 		// only day and month are processed in this example
@@ -238,7 +238,7 @@ func Example_sortDates() {
 	}
 	p.Replace([]Operation{op})
 
-	markers := []Marker{{0, Descending}}
+	markers := []Marker{{Index: 0, Order: Descending}}
 	p.Sort(markers)
 
 	p.Print()
@@ -0,0 +1,396 @@
+package csv
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Similarity defines how similar two values are, from 0 for different values to 1 for equal ones.
+type Similarity int
+
+const (
+	// Levenshtein is 1 minus the edit distance divided by the length of the longer value.
+	Levenshtein = Similarity(iota)
+	// JaroWinkler is the Jaro similarity boosted for values sharing a prefix.
+	JaroWinkler
+	// TokenSet is the share of distinct words found in both values.
+	TokenSet
+	// Phonetic is the share of distinct Soundex codes of words found in both values.
+	Phonetic
+)
+
+var similarityNames = [...]string{"levenshtein", "jaro_winkler", "token_set", "phonetic"}
+
+func (s Similarity) String() string {
+	if s < 0 || int(s) >= len(similarityNames) {
+		return "similarity(" + strconv.Itoa(int(s)) + ")"
+	}
+	return similarityNames[s]
+}
+
+// Score returns the similarity of a and b. Values are compared case-insensitively.
+func (s Similarity) Score(a, b string) float64 {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	switch s {
+	case Levenshtein:
+		return levenshtein(a, b)
+	case JaroWinkler:
+		return jaroWinkler(a, b)
+	case TokenSet:
+		return jaccard(tokens(a), tokens(b))
+	case Phonetic:
+		return jaccard(soundexes(a), soundexes(b))
+	}
+	return 0
+}
+
+func levenshtein(a, b string) float64 {
+	s, t := []rune(a), []rune(b)
+	if len(s) == 0 && len(t) == 0 {
+		return 1
+	}
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	longest := len(s)
+	if len(t) > longest {
+		longest = len(t)
+	}
+	return 1 - float64(prev[len(t)])/float64(longest)
+}
+
+func jaroWinkler(a, b string) float64 {
+	s, t := []rune(a), []rune(b)
+	if len(s) == 0 && len(t) == 0 {
+		return 1
+	}
+	if len(s) == 0 || len(t) == 0 {
+		return 0
+	}
+	window := len(s)
+	if len(t) > window {
+		window = len(t)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	sMatched := make([]bool, len(s))
+	tMatched := make([]bool, len(t))
+	matches := 0
+	for i := range s {
+		for j := maxInt(0, i-window); j < minInt(len(t), i+window+1); j++ {
+			if !tMatched[j] && s[i] == t[j] {
+				sMatched[i], tMatched[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	transpositions, j := 0, 0
+	for i := range s {
+		if !sMatched[i] {
+			continue
+		}
+		for !tMatched[j] {
+			j++
+		}
+		if s[i] != t[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	jaro := (m/float64(len(s)) + m/float64(len(t)) + (m-float64(transpositions/2))/m) / 3
+
+	prefix := 0
+	for prefix < 4 && prefix < len(s) && prefix < len(t) && s[prefix] == t[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// tokens splits v into distinct words made of letters and digits.
+func tokens(v string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, w := range strings.FieldsFunc(v, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		set[w] = struct{}{}
+	}
+	return set
+}
+
+// soundexes returns the distinct Soundex codes of words in v.
+func soundexes(v string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for w := range tokens(v) {
+		set[soundex(w)] = struct{}{}
+	}
+	return set
+}
+
+var soundexDigits = map[rune]byte{
+	'b': '1', 'f': '1', 'p': '1', 'v': '1',
+	'c': '2', 'g': '2', 'j': '2', 'k': '2', 'q': '2', 's': '2', 'x': '2', 'z': '2',
+	'd': '3', 't': '3',
+	'l': '4',
+	'm': '5', 'n': '5',
+	'r': '6',
+}
+
+// soundex returns the American Soundex code of a lower case word, e.g. s530 for smith. Words not starting
+// with an ASCII letter are returned as they are.
+func soundex(w string) string {
+	runes := []rune(w)
+	if len(runes) == 0 || runes[0] < 'a' || runes[0] > 'z' {
+		return w
+	}
+	code := []byte{byte(runes[0])}
+	last := soundexDigits[runes[0]]
+	for _, r := range runes[1:] {
+		if len(code) == 4 {
+			break
+		}
+		d, ok := soundexDigits[r]
+		switch {
+		case ok && d != last:
+			code = append(code, d)
+		case r == 'h' || r == 'w':
+			// h and w do not separate letters with the same code
+			continue
+		}
+		last = d
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	common := 0
+	for k := range a {
+		if _, ok := b[k]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// Blocking defines which rows are compared by FindDuplicates, rows are only compared with rows sharing a block.
+type Blocking int
+
+const (
+	// BlockNone compares all pairs of rows.
+	BlockNone = Blocking(iota)
+	// BlockPrefix compares rows whose first compared values start with the same FuzzyOptions.PrefixLength letters.
+	BlockPrefix
+	// BlockTokens compares rows whose first compared values share the Soundex code of a word.
+	BlockTokens
+)
+
+// FuzzyOptions configures FindDuplicates. Rows are similar when the mean score of the compared columns is at
+// least Threshold, which should be greater than 0 and at most 1. BlockColumns are columns which values must be
+// equal for rows to be compared, they are combined with Blocking. ClusterColumn is the name of the appended
+// column, cluster_id by default.
+type FuzzyOptions struct {
+	Measure       Similarity
+	Threshold     float64
+	Blocking      Blocking
+	PrefixLength  int
+	BlockColumns  []string
+	ClusterColumn string
+}
+
+// FindDuplicates compares the named columns of rows with opts.Measure and groups similar rows, also through
+// other similar rows, into clusters. It appends a column with cluster IDs numbered from 1 in the order of the
+// first rows of clusters, and returns a review Table of the similar pairs with their row positions, score and
+// compared values, ordered by score from the highest. Null cells are never similar.
+func (p *Table) FindDuplicates(names []string, opts FuzzyOptions) (*Table, error) {
+	inds, err := p.titles.indexes(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute FindDuplicates method: %w", err)
+	}
+	if len(inds) == 0 {
+		return nil, fmt.Errorf("failed to execute FindDuplicates method: no column to compare")
+	}
+	blockInds, err := p.titles.indexes(opts.BlockColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute FindDuplicates method: %w", err)
+	}
+	if opts.Measure < Levenshtein || opts.Measure > Phonetic {
+		return nil, fmt.Errorf("failed to execute FindDuplicates method: unknown %s", opts.Measure)
+	}
+	if opts.Blocking < BlockNone || opts.Blocking > BlockTokens {
+		return nil, fmt.Errorf("failed to execute FindDuplicates method: unknown blocking %d", opts.Blocking)
+	}
+	if opts.Blocking == BlockPrefix && opts.PrefixLength <= 0 {
+		return nil, fmt.Errorf("failed to execute FindDuplicates method: prefix length should be positive, but it is %d", opts.PrefixLength)
+	}
+	if opts.Threshold <= 0 || opts.Threshold > 1 {
+		return nil, fmt.Errorf("failed to execute FindDuplicates method: threshold should be in (0, 1], but it is %g", opts.Threshold)
+	}
+	cluster := opts.ClusterColumn
+	if cluster == "" {
+		cluster = "cluster_id"
+	}
+	if err = checkUniqueNames(append(p.titles.names(), cluster)); err != nil {
+		return nil, fmt.Errorf("failed to execute FindDuplicates method: %w", err)
+	}
+
+	isNull := p.nullChecker()
+	blocks := make(map[string][]int)
+	var blockOrder []string
+	for r, row := range p.rows {
+		for _, b := range blockKeys(row[inds[0]], opts) {
+			k := rowKey(append([]string{b}, cellsAt(row, blockInds)...), seq(len(blockInds)+1))
+			if _, ok := blocks[k]; !ok {
+				blockOrder = append(blockOrder, k)
+			}
+			blocks[k] = append(blocks[k], r)
+		}
+	}
+
+	type pair struct {
+		a, b  int
+		score float64
+	}
+	var pairs []pair
+	// only BlockTokens puts a row into more than one block, so only then pairs can be compared again
+	var compared map[[2]int]bool
+	if opts.Blocking == BlockTokens {
+		compared = make(map[[2]int]bool)
+	}
+	parents := seq(len(p.rows))
+	var find func(r int) int
+	find = func(r int) int {
+		if parents[r] != r {
+			parents[r] = find(parents[r])
+		}
+		return parents[r]
+	}
+	for _, k := range blockOrder {
+		rows := blocks[k]
+		for i, a := range rows {
+			for _, b := range rows[i+1:] {
+				if compared != nil {
+					if compared[[2]int{a, b}] {
+						continue
+					}
+					compared[[2]int{a, b}] = true
+				}
+				score := 0.0
+				for _, c := range inds {
+					v, w := p.rows[a][c], p.rows[b][c]
+					if !nullAt(isNull, c, v) && !nullAt(isNull, c, w) {
+						score += opts.Measure.Score(v, w)
+					}
+				}
+				score /= float64(len(inds))
+				if score >= opts.Threshold {
+					pairs = append(pairs, pair{a: a, b: b, score: score})
+					if ra, rb := find(a), find(b); ra != rb {
+						parents[maxInt(ra, rb)] = minInt(ra, rb)
+					}
+				}
+			}
+		}
+	}
+
+	ids := make(map[int]int)
+	p.titles[cluster] = len(p.titles)
+	for r := range p.rows {
+		root := find(r)
+		if _, ok := ids[root]; !ok {
+			ids[root] = len(ids) + 1
+		}
+		p.rows[r] = append(p.rows[r], strconv.Itoa(ids[root]))
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i].score != pairs[j].score {
+			return pairs[i].score > pairs[j].score
+		}
+		if pairs[i].a != pairs[j].a {
+			return pairs[i].a < pairs[j].a
+		}
+		return pairs[i].b < pairs[j].b
+	})
+	titles := []string{"row_a", "row_b", "score", cluster}
+	for _, n := range names {
+		titles = append(titles, n+"_a", n+"_b")
+	}
+	review := &Table{titles: createTitle(titles), rows: make([][]string, 0, len(pairs))}
+	for _, pr := range pairs {
+		out := []string{strconv.Itoa(pr.a), strconv.Itoa(pr.b), strconv.FormatFloat(pr.score, 'f', 4, 64), strconv.Itoa(ids[find(pr.a)])}
+		for _, c := range inds {
+			out = append(out, p.rows[pr.a][c], p.rows[pr.b][c])
+		}
+		review.rows = append(review.rows, out)
+	}
+	return review, nil
+}
+
+// blockKeys returns the blocks of a row by the first compared value v.
+func blockKeys(v string, opts FuzzyOptions) []string {
+	switch opts.Blocking {
+	case BlockPrefix:
+		runes := []rune(strings.ToLower(strings.TrimSpace(v)))
+		if len(runes) > opts.PrefixLength {
+			runes = runes[:opts.PrefixLength]
+		}
+		return []string{string(runes)}
+	case BlockTokens:
+		var keys []string
+		for k := range soundexes(strings.ToLower(v)) {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	return []string{""}
+}
+
+func cellsAt(row []string, inds []int) []string {
+	cells := make([]string, len(inds))
+	for i, c := range inds {
+		cells[i] = row[c]
+	}
+	return cells
+}
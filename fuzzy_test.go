@@ -0,0 +1,108 @@
+package csv
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSimilarity_Score(t *testing.T) {
+	tests := []struct {
+		measure Similarity
+		a, b    string
+		want    float64
+	}{
+		{Levenshtein, "kitten", "sitting", 1 - 3.0/7},
+		{Levenshtein, "", "", 1},
+		{JaroWinkler, "MARTHA", "MARHTA", 0.9611},
+		{JaroWinkler, "DIXON", "DICKSONX", 0.8133},
+		{JaroWinkler, "abc", "", 0},
+		{TokenSet, "Smith, John", "john smith", 1},
+		{TokenSet, "J. Smith", "John Smith", 1.0 / 3},
+		{Phonetic, "Jon Smith", "John Smyth", 1},
+	}
+	for _, tt := range tests {
+		if got := tt.measure.Score(tt.a, tt.b); math.Abs(got-tt.want) > 0.0001 {
+			t.Errorf("%s(%q, %q) = %.4f, want %.4f", tt.measure, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func Test_soundex(t *testing.T) {
+	for w, want := range map[string]string{"robert": "r163", "rupert": "r163", "ashcraft": "a261", "tymczak": "t522", "pfister": "p236", "42": "42"} {
+		if got := soundex(w); got != want {
+			t.Errorf("soundex(%s) = %s, want %s", w, got, want)
+		}
+	}
+}
+
+func TestTable_FindDuplicates(t *testing.T) {
+	crm := func() *Table {
+		return &Table{
+			titles: createTitle([]string{"name", "city"}),
+			rows: [][]string{
+				{"Jon Smith", "Leeds"},
+				{"Ann Jones", "York"},
+				{"jon smith", "Leeds"},
+				{"Jonh Smith", "Leeds"},
+				{"Anne Jones", "Hull"},
+			},
+		}
+	}
+
+	for _, blocking := range []Blocking{BlockNone, BlockPrefix, BlockTokens} {
+		p := crm()
+		review, err := p.FindDuplicates([]string{"name"}, FuzzyOptions{Measure: JaroWinkler, Threshold: 0.9, Blocking: blocking, PrefixLength: 2})
+		if err != nil {
+			t.Fatalf("FindDuplicates failed: %s", err)
+		}
+		var ids []string
+		for _, row := range p.rows {
+			ids = append(ids, row[2])
+		}
+		if want := []string{"1", "2", "1", "1", "2"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("FindDuplicates with blocking %d cluster IDs = %v, want %v", blocking, ids, want)
+		}
+		if n := len(review.rows); n != 4 {
+			t.Errorf("FindDuplicates with blocking %d should review 4 pairs, but got %v", blocking, review.rows)
+		}
+		if review.rows[0][0] != "0" || review.rows[0][1] != "2" || review.rows[0][2] != "1.0000" {
+			t.Errorf("FindDuplicates should review the case-insensitive equal pair first, but got %v", review.rows[0])
+		}
+	}
+
+	t.Run("Block columns", func(t *testing.T) {
+		p := crm()
+		review, err := p.FindDuplicates([]string{"name"}, FuzzyOptions{Measure: Levenshtein, Threshold: 0.8, BlockColumns: []string{"city"}, ClusterColumn: "dup"})
+		if err != nil {
+			t.Fatalf("FindDuplicates failed: %s", err)
+		}
+		if p.rows[1][2] == p.rows[4][2] {
+			t.Errorf("FindDuplicates should not compare rows of different cities, but got %v", p.rows)
+		}
+		if _, ok := review.titles["name_b"]; !ok || len(review.rows) != 3 {
+			t.Errorf("FindDuplicates review = %v %v", review.titles.names(), review.rows)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		p := crm()
+		if _, err := p.FindDuplicates([]string{"missing"}, FuzzyOptions{Threshold: 0.9}); err == nil {
+			t.Error("FindDuplicates should fail on a missing column")
+		}
+		if _, err := p.FindDuplicates([]string{"name"}, FuzzyOptions{Threshold: 0.9, Blocking: BlockPrefix}); err == nil {
+			t.Error("FindDuplicates should fail on a missing prefix length")
+		}
+		if _, err := p.FindDuplicates([]string{"name"}, FuzzyOptions{Threshold: 0.9, ClusterColumn: "city"}); err == nil {
+			t.Error("FindDuplicates should fail on an existing cluster column")
+		}
+		for _, threshold := range []float64{0, -0.5, 1.5} {
+			if _, err := p.FindDuplicates([]string{"name"}, FuzzyOptions{Threshold: threshold}); err == nil {
+				t.Errorf("FindDuplicates should fail on threshold %g", threshold)
+			}
+		}
+		if len(p.titles) != 2 {
+			t.Errorf("Failed FindDuplicates should not add a column, but titles are %v", p.titles.names())
+		}
+	})
+}
@@ -0,0 +1,266 @@
+package csv
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// AggregateFunc defines how values of a column in a group are aggregated.
+type AggregateFunc int
+
+const (
+	// Count counts non-null values, or rows when the column of Aggregate is empty.
+	Count = AggregateFunc(iota)
+	// CountDistinct counts distinct non-null values.
+	CountDistinct
+	// Sum adds numbers exactly.
+	Sum
+	// Mean is the average of numbers, it has two more digits after the decimal point than the values.
+	Mean
+	// Min is the smallest value, numbers are compared as numbers when all values of a group are numbers.
+	Min
+	// Max is the largest value, numbers are compared as numbers when all values of a group are numbers.
+	Max
+	// First is the first non-null value.
+	First
+	// Last is the last non-null value.
+	Last
+	// StringJoin joins non-null values with the separator of Aggregate, "," by default.
+	StringJoin
+)
+
+var aggregateNames = [...]string{"count", "count_distinct", "sum", "mean", "min", "max", "first", "last", "join"}
+
+func (f AggregateFunc) String() string {
+	if f < 0 || int(f) >= len(aggregateNames) {
+		return "aggregate(" + strconv.Itoa(int(f)) + ")"
+	}
+	return aggregateNames[f]
+}
+
+// Aggregate defines an aggregated column. Name is the new column name, column_func, e.g. scores_sum,
+// is used when it is empty.
+type Aggregate struct {
+	Column    string
+	Func      AggregateFunc
+	Name      string
+	Separator string
+}
+
+func (a Aggregate) name() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	if a.Column == "" {
+		return a.Func.String()
+	}
+	return a.Column + "_" + a.Func.String()
+}
+
+// CellError describes a cell which cannot be used, Row is zero-based and does not count titles.
+type CellError struct {
+	Row    int
+	Column string
+	Value  string
+	Err    error
+}
+
+func (e CellError) Error() string {
+	return fmt.Sprintf("row %d, column %s: %q: %s", e.Row, e.Column, e.Value, e.Err)
+}
+
+// parseNumber parses a number robustly: a plain decimal first and then a number in English format, e.g. "$1,234.50".
+func parseNumber(v string) (Decimal, error) {
+	if d, err := ParseDecimal(v); err == nil {
+		return d, nil
+	}
+	return LocaleEnglish.Parse(v)
+}
+
+// group is a group of rows with the same key.
+type group struct {
+	key  []string
+	rows []int
+}
+
+// groupRows groups rows by the columns at inds in first-seen order. Null keys are grouped together as other values are.
+func (p *Table) groupRows(inds []int) []*group {
+	index := make(map[string]*group)
+	var groups []*group
+	for r, row := range p.rows {
+		k := rowKey(row, inds)
+		g, ok := index[k]
+		if !ok {
+			g = &group{key: make([]string, len(inds))}
+			for i, c := range inds {
+				g.key[i] = row[c]
+			}
+			index[k] = g
+			groups = append(groups, g)
+		}
+		g.rows = append(g.rows, r)
+	}
+	return groups
+}
+
+// GroupBy groups rows by the named columns in the order of their first appearance, so no sorting is needed,
+// and creates a new Table of the key columns and the aggregated columns. Nulls are skipped by aggregations.
+// Cells which cannot be parsed as numbers by Sum and Mean are skipped and returned as CellErrors.
+func (p *Table) GroupBy(names []string, aggs []Aggregate) (*Table, []CellError, error) {
+	inds, err := p.titles.indexes(names)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute GroupBy method: %w", err)
+	}
+	cols := make([]int, len(aggs))
+	titles := append([]string{}, names...)
+	for i, a := range aggs {
+		cols[i] = -1
+		if a.Func < Count || a.Func > StringJoin {
+			return nil, nil, fmt.Errorf("failed to execute GroupBy method: unknown %s", a.Func)
+		}
+		if a.Column != "" {
+			c, exists := p.titles[a.Column]
+			if !exists {
+				return nil, nil, fmt.Errorf("failed to execute GroupBy method: %w", TitleNotFound(a.Column))
+			}
+			cols[i] = c
+		} else if a.Func != Count {
+			return nil, nil, fmt.Errorf("failed to execute GroupBy method: %s needs a column", a.Func)
+		}
+		titles = append(titles, a.name())
+	}
+	if err = checkUniqueNames(titles); err != nil {
+		return nil, nil, fmt.Errorf("failed to execute GroupBy method: %w", err)
+	}
+
+	isNull := p.nullChecker()
+	var skipped []CellError
+	groups := p.groupRows(inds)
+	rows := make([][]string, len(groups))
+	for g, grp := range groups {
+		row := append([]string{}, grp.key...)
+		for i, a := range aggs {
+			var values []string
+			var rowsOfValues []int
+			for _, r := range grp.rows {
+				if cols[i] < 0 {
+					values = append(values, "")
+					rowsOfValues = append(rowsOfValues, r)
+					continue
+				}
+				v := p.rows[r][cols[i]]
+				if !nullAt(isNull, cols[i], v) {
+					values = append(values, v)
+					rowsOfValues = append(rowsOfValues, r)
+				}
+			}
+			v, errs := aggregate(a, values)
+			for _, e := range errs {
+				e.Row, e.Column = rowsOfValues[e.Row], a.Column
+				skipped = append(skipped, e)
+			}
+			row = append(row, v)
+		}
+		rows[g] = row
+	}
+	return &Table{titles: createTitle(titles), rows: rows}, skipped, nil
+}
+
+// aggregate computes an aggregation of non-null values. Rows of returned CellErrors are indexes of values.
+func aggregate(a Aggregate, values []string) (string, []CellError) {
+	switch a.Func {
+	case Count:
+		return strconv.Itoa(len(values)), nil
+	case CountDistinct:
+		distinct := make(map[string]struct{}, len(values))
+		for _, v := range values {
+			distinct[v] = struct{}{}
+		}
+		return strconv.Itoa(len(distinct)), nil
+	case Sum, Mean:
+		var total Decimal
+		var errs []CellError
+		n := 0
+		for i, v := range values {
+			d, err := parseNumber(v)
+			if err != nil {
+				errs = append(errs, CellError{Row: i, Value: v, Err: err})
+				continue
+			}
+			total = total.Add(d)
+			n++
+		}
+		if n == 0 {
+			return "", errs
+		}
+		if a.Func == Sum {
+			return total.String(), errs
+		}
+		mean, _ := total.Quo(Decimal{unscaled: big.NewInt(int64(n))}, total.scale+2, HalfEven)
+		return mean.String(), errs
+	case Min, Max:
+		return extreme(values, a.Func == Min), nil
+	case First:
+		if len(values) == 0 {
+			return "", nil
+		}
+		return values[0], nil
+	case Last:
+		if len(values) == 0 {
+			return "", nil
+		}
+		return values[len(values)-1], nil
+	case StringJoin:
+		sep := a.Separator
+		if sep == "" {
+			sep = ","
+		}
+		return strings.Join(values, sep), nil
+	}
+	return "", nil
+}
+
+// extreme finds the smallest or the largest value, numerically when all values are numbers.
+func extreme(values []string, smallest bool) string {
+	if len(values) == 0 {
+		return ""
+	}
+	numbers := make([]Decimal, len(values))
+	numeric := true
+	for i, v := range values {
+		d, err := parseNumber(v)
+		if err != nil {
+			numeric = false
+			break
+		}
+		numbers[i] = d
+	}
+
+	best := 0
+	for i := 1; i < len(values); i++ {
+		var order int
+		if numeric {
+			order = numbers[i].Cmp(numbers[best])
+		} else {
+			order = compare(values[i], values[best])
+		}
+		if (smallest && order < 0) || (!smallest && order > 0) {
+			best = i
+		}
+	}
+	return values[best]
+}
+
+// checkUniqueNames returns an error when a name appears more than once.
+func checkUniqueNames(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, n := range names {
+		if seen[n] {
+			return fmt.Errorf("column %s appears more than once", n)
+		}
+		seen[n] = true
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_GroupBy(t *testing.T) {
+	p := &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
+	p.rows[4][2] = "N/A"
+	p.rows[7][2] = "lots"
+	p.SetNulls(DefaultNulls)
+
+	g, skipped, err := p.GroupBy([]string{"sub"}, []Aggregate{
+		{Func: Count},
+		{Column: "user", Func: CountDistinct},
+		{Column: "scores", Func: Sum},
+		{Column: "scores", Func: Mean},
+		{Column: "scores", Func: Min},
+		{Column: "scores", Func: Max, Name: "best"},
+		{Column: "user", Func: First},
+		{Column: "user", Func: Last},
+		{Column: "user", Func: StringJoin, Separator: "|"},
+	})
+	if err != nil {
+		t.Fatalf("GroupBy failed: %s", err)
+	}
+
+	wantTitles := []string{"sub", "count", "user_count_distinct", "scores_sum", "scores_mean", "scores_min", "best", "user_first", "user_last", "user_join"}
+	if got := g.titles.names(); !reflect.DeepEqual(got, wantTitles) {
+		t.Errorf("GroupBy titles = %v, want %v", got, wantTitles)
+	}
+	want := [][]string{
+		{"Go", "5", "5", "700", "175.00", "100", "200", "gri", "ken", "gri|glenda|rsc|r|ken"},
+		{"C", "3", "3", "250", "125.00", "100", "lots", "ken", "r", "ken|dmr|r"},
+		{"Smalltalk", "1", "1", "80", "80.00", "80", "80", "gri", "gri", "gri"},
+	}
+	if !reflect.DeepEqual(g.rows, want) {
+		t.Errorf("GroupBy rows = %v, want %v", g.rows, want)
+	}
+
+	if len(skipped) != 2 || skipped[0].Row != 7 || skipped[0].Column != "scores" || skipped[0].Value != "lots" {
+		t.Errorf("Want row 7 of scores reported twice by sum and mean, but got %v", skipped)
+	}
+
+	for _, aggs := range [][]Aggregate{
+		{{Column: "missing", Func: Sum}},
+		{{Func: Sum}},
+		{{Column: "user", Func: First, Name: "sub"}},
+	} {
+		if _, _, err = p.GroupBy([]string{"sub"}, aggs); err == nil {
+			t.Errorf("GroupBy should fail with %v", aggs)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package csv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IndexOptions configures an Index. When Unique is true, building the Index fails on a duplicated key.
+// When Ordered is true, the Index also keeps rows ordered by the key columns for Range queries.
+type IndexOptions struct {
+	Unique  bool
+	Ordered bool
+}
+
+// Index is a hash index on named columns of a Table for exact-match lookups, and optionally an ordered index
+// for range queries. Rows with a null key are not indexed. When Sort, Filter, Replace or other methods change
+// the rows, the Index is rebuilt on its next use.
+type Index struct {
+	table   *Table
+	names   []string
+	cols    []int
+	opts    IndexOptions
+	version int
+	keys    map[string][]int
+	// ordered are row positions ordered by markers, only for an ordered Index
+	ordered []int
+	markers []Marker
+}
+
+// Index builds an Index on the named columns. The ordered index compares values as Sort does in ascending order.
+func (p *Table) Index(names []string, opts IndexOptions) (*Index, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("failed to execute Index method: no key column")
+	}
+	ix := &Index{table: p, names: names, opts: opts}
+	if err := ix.build(); err != nil {
+		return nil, fmt.Errorf("failed to execute Index method: %w", err)
+	}
+	return ix, nil
+}
+
+// build indexes the current rows of the Table. Columns are found by names again, as Swap moves them.
+func (ix *Index) build() error {
+	p := ix.table
+	cols, err := p.titles.indexes(ix.names)
+	if err != nil {
+		return err
+	}
+	ix.cols = cols
+	isNull := p.nullChecker()
+	ix.keys = make(map[string][]int, len(p.rows))
+	ix.ordered = nil
+	for r, row := range p.rows {
+		if hasNullKey(isNull, row, ix.cols) {
+			continue
+		}
+		k := rowKey(row, ix.cols)
+		if ix.opts.Unique && len(ix.keys[k]) > 0 {
+			return fmt.Errorf("rows %d and %d have the same key %s", ix.keys[k][0], r, strings.Join(cellsAt(row, ix.cols), ", "))
+		}
+		ix.keys[k] = append(ix.keys[k], r)
+		if ix.opts.Ordered {
+			ix.ordered = append(ix.ordered, r)
+		}
+	}
+	if ix.opts.Ordered {
+		ix.markers = make([]Marker, len(ix.cols))
+		for i, c := range ix.cols {
+			ix.markers[i] = Marker{Index: c, Order: Ascending}
+		}
+		cmp := p.rowComparer(ix.markers)
+		sort.SliceStable(ix.ordered, func(i, j int) bool { return cmp(p.rows[ix.ordered[i]], p.rows[ix.ordered[j]]) < 0 })
+	}
+	ix.version = p.version
+	return nil
+}
+
+// refresh rebuilds the Index when the Table has changed since it was built.
+func (ix *Index) refresh() error {
+	if ix.version == ix.table.version && ix.keys != nil {
+		return nil
+	}
+	if err := ix.build(); err != nil {
+		ix.keys = nil
+		return fmt.Errorf("failed to rebuild the index on %s: %w", strings.Join(ix.names, ", "), err)
+	}
+	return nil
+}
+
+// Lookup returns the rows with the key made of values, in the order of the Table. The rows are views which share
+// storage with the Table, they should not be modified. The error is from rebuilding the Index, e.g. when the
+// rows of a unique Index have a duplicated key after a change.
+func (ix *Index) Lookup(values ...string) ([][]string, error) {
+	if len(values) != len(ix.cols) {
+		return nil, fmt.Errorf("the index on %s needs %d values, but got %d", strings.Join(ix.names, ", "), len(ix.cols), len(values))
+	}
+	if err := ix.refresh(); err != nil {
+		return nil, err
+	}
+	matches := ix.keys[rowKey(values, seq(len(values)))]
+	rows := make([][]string, len(matches))
+	for i, r := range matches {
+		rows[i] = ix.table.rows[r]
+	}
+	return rows, nil
+}
+
+// Range returns the rows with keys from from to to, both included, in the order of keys. A nil bound means
+// no limit. A bound can have fewer values than the key columns, then only the leading columns are compared.
+// The rows are views as those of Lookup. Range needs an ordered Index.
+func (ix *Index) Range(from, to []string) ([][]string, error) {
+	if !ix.opts.Ordered {
+		return nil, fmt.Errorf("the index on %s is not ordered", strings.Join(ix.names, ", "))
+	}
+	if len(from) > len(ix.cols) || len(to) > len(ix.cols) {
+		return nil, fmt.Errorf("the index on %s has %d key columns, but bounds have %d and %d values", strings.Join(ix.names, ", "), len(ix.cols), len(from), len(to))
+	}
+	if err := ix.refresh(); err != nil {
+		return nil, err
+	}
+
+	p := ix.table
+	// search finds the first ordered row whose key compared with bound by the leading columns of bound meets ok
+	search := func(bound []string, ok func(order int) bool) int {
+		cmp := p.rowComparer(ix.markers[:len(bound)])
+		probe := make([]string, len(p.titles))
+		for i, v := range bound {
+			probe[ix.cols[i]] = v
+		}
+		return sort.Search(len(ix.ordered), func(i int) bool { return ok(cmp(p.rows[ix.ordered[i]], probe)) })
+	}
+	start, end := 0, len(ix.ordered)
+	if from != nil {
+		start = search(from, func(order int) bool { return order >= 0 })
+	}
+	if to != nil {
+		end = search(to, func(order int) bool { return order > 0 })
+	}
+
+	var rows [][]string
+	for i := start; i < end; i++ {
+		rows = append(rows, p.rows[ix.ordered[i]])
+	}
+	return rows, nil
+}
@@ -0,0 +1,112 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_Index(t *testing.T) {
+	products := func() *Table {
+		return &Table{
+			titles: createTitle([]string{"sku", "category", "price"}),
+			rows: [][]string{
+				{"p1", "pens", "1.5"},
+				{"p2", "ink", "12"},
+				{"p3", "pens", "3"},
+				{"p4", "pads", "2.25"},
+			},
+		}
+	}
+
+	t.Run("Lookup", func(t *testing.T) {
+		p := products()
+		ix, err := p.Index([]string{"category"}, IndexOptions{})
+		if err != nil {
+			t.Fatalf("Index failed: %s", err)
+		}
+		got, err := ix.Lookup("pens")
+		if err != nil {
+			t.Fatalf("Lookup failed: %s", err)
+		}
+		if want := [][]string{{"p1", "pens", "1.5"}, {"p3", "pens", "3"}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Lookup = %v, want %v", got, want)
+		}
+		if got, _ = ix.Lookup("none"); len(got) != 0 {
+			t.Errorf("Lookup of a missing key = %v", got)
+		}
+		if _, err = ix.Lookup("pens", "1.5"); err == nil {
+			t.Error("Lookup should fail on a wrong number of values")
+		}
+
+		p.Filter([]Isfunc{func(row []string) bool { return row[0] != "p1" }})
+		if got, _ = ix.Lookup("pens"); !reflect.DeepEqual(got, [][]string{{"p3", "pens", "3"}}) {
+			t.Errorf("Lookup after Filter = %v", got)
+		}
+		if err = p.Swap("sku", "category"); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ = ix.Lookup("ink"); !reflect.DeepEqual(got, [][]string{{"ink", "p2", "12"}}) {
+			t.Errorf("Lookup after Swap = %v", got)
+		}
+	})
+
+	t.Run("Unique", func(t *testing.T) {
+		p := products()
+		if _, err := p.Index([]string{"category"}, IndexOptions{Unique: true}); err == nil {
+			t.Error("Index should fail on duplicated keys of a unique index")
+		}
+		ix, err := p.Index([]string{"sku"}, IndexOptions{Unique: true})
+		if err != nil {
+			t.Fatalf("Index failed: %s", err)
+		}
+		p.Replace([]Operation{{
+			Check: func(row []string) bool { return row[0] == "p4" },
+			Act:   func(row []string) { row[0] = "p1" },
+		}})
+		if _, err = ix.Lookup("p1"); err == nil {
+			t.Error("Lookup should fail when Replace makes duplicated keys of a unique index")
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		p := products()
+		if err := p.SetDecimalColumn("price", DecimalFormat{}); err != nil {
+			t.Fatal(err)
+		}
+		ix, err := p.Index([]string{"price"}, IndexOptions{Ordered: true})
+		if err != nil {
+			t.Fatalf("Index failed: %s", err)
+		}
+		got, err := ix.Range([]string{"2"}, []string{"12"})
+		if err != nil {
+			t.Fatalf("Range failed: %s", err)
+		}
+		if want := [][]string{{"p4", "pads", "2.25"}, {"p3", "pens", "3"}, {"p2", "ink", "12"}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Range = %v, want %v", got, want)
+		}
+
+		p.Sort([]Marker{{Index: 0, Order: Descending}})
+		if got, _ = ix.Range(nil, []string{"2.25"}); !reflect.DeepEqual(got, [][]string{{"p1", "pens", "1.5"}, {"p4", "pads", "2.25"}}) {
+			t.Errorf("Range after Sort = %v", got)
+		}
+
+		ix, err = p.Index([]string{"category", "price"}, IndexOptions{Ordered: true})
+		if err != nil {
+			t.Fatalf("Index failed: %s", err)
+		}
+		if got, _ = ix.Range([]string{"pens"}, []string{"pens"}); len(got) != 2 || got[0][0] != "p1" {
+			t.Errorf("Range by a leading column = %v", got)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		p := products()
+		if _, err := p.Index([]string{"missing"}, IndexOptions{}); err == nil {
+			t.Error("Index should fail on a missing column")
+		}
+		ix, _ := p.Index([]string{"sku"}, IndexOptions{})
+		if _, err := ix.Range(nil, nil); err == nil {
+			t.Error("Range should fail on an index which is not ordered")
+		}
+	})
+}
@@ -0,0 +1,217 @@
+package csv
+
+import (
+	"fmt"
+)
+
+// JoinKind defines which unmatched rows are kept by a join.
+type JoinKind int
+
+const (
+	// InnerJoin keeps matched rows only.
+	InnerJoin = JoinKind(iota)
+	// LeftJoin keeps all rows of the left Table.
+	LeftJoin
+	// RightJoin keeps all rows of the right Table.
+	RightJoin
+	// FullJoin keeps all rows of both Tables.
+	FullJoin
+)
+
+const (
+	defaultLeftSuffix  = "_left"
+	defaultRightSuffix = "_right"
+)
+
+// JoinOptions configures a join. RightKeys are names of key columns of the right Table, they are the same as
+// the left ones when it is nil. Clashing non-key column names get suffixes, "_left" and "_right" by default,
+// and a right non-key column named as a left key column gets the right suffix.
+// Fill is the value of cells of the missing side of unmatched rows.
+type JoinOptions struct {
+	Kind        JoinKind
+	RightKeys   []string
+	LeftSuffix  string
+	RightSuffix string
+	Fill        string
+}
+
+// joinLayout defines the titles of a joined Table and how to build a joined row. Key columns come first
+// in the order of the left Table, followed by the other columns of the left and then the right Table.
+type joinLayout struct {
+	titles     []string
+	leftKeys   []int
+	rightKeys  []int
+	leftOther  []int
+	rightOther []int
+	fill       string
+}
+
+func newJoinLayout(left, right *Table, names []string, opts JoinOptions) (*joinLayout, error) {
+	rightNames := opts.RightKeys
+	if rightNames == nil {
+		rightNames = names
+	}
+	if len(names) != len(rightNames) {
+		return nil, fmt.Errorf("both sides need the same number of key columns, but have %d and %d", len(names), len(rightNames))
+	}
+	lk, err := left.titles.indexes(names)
+	if err != nil {
+		return nil, err
+	}
+	rk, err := right.titles.indexes(rightNames)
+	if err != nil {
+		return nil, err
+	}
+
+	leftSuffix, rightSuffix := opts.LeftSuffix, opts.RightSuffix
+	if leftSuffix == "" {
+		leftSuffix = defaultLeftSuffix
+	}
+	if rightSuffix == "" {
+		rightSuffix = defaultRightSuffix
+	}
+
+	l := &joinLayout{titles: append([]string{}, names...), leftKeys: lk, rightKeys: rk, fill: opts.Fill}
+	isKey := func(keys []int, c int) bool {
+		for _, k := range keys {
+			if k == c {
+				return true
+			}
+		}
+		return false
+	}
+	leftNames, rightAll := left.titles.names(), right.titles.names()
+	for c := range leftNames {
+		if !isKey(lk, c) {
+			l.leftOther = append(l.leftOther, c)
+		}
+	}
+	for c := range rightAll {
+		if !isKey(rk, c) {
+			l.rightOther = append(l.rightOther, c)
+		}
+	}
+
+	clash := make(map[string]bool)
+	for _, c := range l.rightOther {
+		clash[rightAll[c]] = true
+	}
+	var others []string
+	for _, c := range l.leftOther {
+		n := leftNames[c]
+		if clash[n] {
+			n += leftSuffix
+		}
+		others = append(others, n)
+	}
+	// key columns have the left names, so right columns are also renamed when they clash with them
+	clash = make(map[string]bool)
+	for _, n := range names {
+		clash[n] = true
+	}
+	for _, c := range l.leftOther {
+		clash[leftNames[c]] = true
+	}
+	for _, c := range l.rightOther {
+		n := rightAll[c]
+		if clash[n] {
+			n += rightSuffix
+		}
+		others = append(others, n)
+	}
+
+	l.titles = append(l.titles, others...)
+	seen := make(map[string]bool, len(l.titles))
+	for _, n := range l.titles {
+		if seen[n] {
+			return nil, fmt.Errorf("column %s appears more than once in the joined table, choose other suffixes", n)
+		}
+		seen[n] = true
+	}
+	return l, nil
+}
+
+// row creates a joined row, a nil side is filled with the fill value. Keys are taken from the left side if it exists.
+func (l *joinLayout) row(left, right []string) []string {
+	out := make([]string, 0, len(l.titles))
+	for i := range l.leftKeys {
+		if left != nil {
+			out = append(out, left[l.leftKeys[i]])
+		} else {
+			out = append(out, right[l.rightKeys[i]])
+		}
+	}
+	for _, c := range l.leftOther {
+		if left != nil {
+			out = append(out, left[c])
+		} else {
+			out = append(out, l.fill)
+		}
+	}
+	for _, c := range l.rightOther {
+		if right != nil {
+			out = append(out, right[c])
+		} else {
+			out = append(out, l.fill)
+		}
+	}
+	return out
+}
+
+// hasNullKey reports if any key of a row is a null, null keys never match.
+func hasNullKey(isNull func(col int, v string) bool, row []string, keys []int) bool {
+	for _, k := range keys {
+		if nullAt(isNull, k, row[k]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Join relates rows of two Tables by named key columns and creates a new Table. It builds a hash table of
+// the right Table, so neither Table needs sorting. Rows are in the order of the left Table, each followed by
+// its matches in the order of the right Table; unmatched right rows of right and full joins come last.
+// As in SQL, a row with a null key never matches.
+func (p *Table) Join(right *Table, names []string, opts JoinOptions) (*Table, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("failed to execute Join method: no key column")
+	}
+	l, err := newJoinLayout(p, right, names, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Join method: %w", err)
+	}
+
+	leftNull, rightNull := p.nullChecker(), right.nullChecker()
+	index := make(map[string][]int)
+	for r, row := range right.rows {
+		if !hasNullKey(rightNull, row, l.rightKeys) {
+			k := rowKey(row, l.rightKeys)
+			index[k] = append(index[k], r)
+		}
+	}
+
+	matched := make([]bool, len(right.rows))
+	var rows [][]string
+	for _, row := range p.rows {
+		var matches []int
+		if !hasNullKey(leftNull, row, l.leftKeys) {
+			matches = index[rowKey(row, l.leftKeys)]
+		}
+		for _, r := range matches {
+			matched[r] = true
+			rows = append(rows, l.row(row, right.rows[r]))
+		}
+		if len(matches) == 0 && (opts.Kind == LeftJoin || opts.Kind == FullJoin) {
+			rows = append(rows, l.row(row, nil))
+		}
+	}
+	if opts.Kind == RightJoin || opts.Kind == FullJoin {
+		for r, row := range right.rows {
+			if !matched[r] {
+				rows = append(rows, l.row(nil, row))
+			}
+		}
+	}
+
+	return &Table{titles: createTitle(l.titles), rows: rows}, nil
+}
@@ -0,0 +1,126 @@
+package csv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func customers() *Table {
+	return &Table{
+		titles: createTitle([]string{"id", "name", "city"}),
+		rows: [][]string{
+			{"1", "Rob", "Sydney"},
+			{"2", "Ken", "Melbourne"},
+			{"3", "Robert", "Perth"},
+			{"", "Nobody", "Darwin"},
+		},
+	}
+}
+
+func orders() *Table {
+	return &Table{
+		titles: createTitle([]string{"order", "id", "city"}),
+		rows: [][]string{
+			{"o1", "1", "Sydney"},
+			{"o2", "2", "Hobart"},
+			{"o3", "1", "Sydney"},
+			{"o4", "9", "Cairns"},
+			{"o5", "", "Darwin"},
+		},
+	}
+}
+
+func joined(p *Table) []string {
+	lines := []string{strings.Join(p.titles.names(), ",")}
+	for _, r := range p.rows {
+		lines = append(lines, strings.Join(r, ","))
+	}
+	return lines
+}
+
+func TestTable_Join(t *testing.T) {
+	tests := []struct {
+		name string
+		kind JoinKind
+		want []string
+	}{
+		{"Inner", InnerJoin, []string{
+			"id,name,city_left,order,city_right",
+			"1,Rob,Sydney,o1,Sydney",
+			"1,Rob,Sydney,o3,Sydney",
+			"2,Ken,Melbourne,o2,Hobart",
+		}},
+		{"Left", LeftJoin, []string{
+			"id,name,city_left,order,city_right",
+			"1,Rob,Sydney,o1,Sydney",
+			"1,Rob,Sydney,o3,Sydney",
+			"2,Ken,Melbourne,o2,Hobart",
+			"3,Robert,Perth,?,?",
+			",Nobody,Darwin,?,?",
+		}},
+		{"Right", RightJoin, []string{
+			"id,name,city_left,order,city_right",
+			"1,Rob,Sydney,o1,Sydney",
+			"1,Rob,Sydney,o3,Sydney",
+			"2,Ken,Melbourne,o2,Hobart",
+			"9,?,?,o4,Cairns",
+			",?,?,o5,Darwin",
+		}},
+		{"Full", FullJoin, []string{
+			"id,name,city_left,order,city_right",
+			"1,Rob,Sydney,o1,Sydney",
+			"1,Rob,Sydney,o3,Sydney",
+			"2,Ken,Melbourne,o2,Hobart",
+			"3,Robert,Perth,?,?",
+			",Nobody,Darwin,?,?",
+			"9,?,?,o4,Cairns",
+			",?,?,o5,Darwin",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right := customers(), orders()
+			left.SetNulls([]string{""})
+			right.SetNulls([]string{""})
+			p, err := left.Join(right, []string{"id"}, JoinOptions{Kind: tt.kind, Fill: "?"})
+			if err != nil {
+				t.Fatalf("Join failed: %s", err)
+			}
+			if got := joined(p); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Join = \n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestTable_Join_keys(t *testing.T) {
+	p, err := customers().Join(orders(), []string{"id", "city"}, JoinOptions{})
+	if err != nil {
+		t.Fatalf("Join failed: %s", err)
+	}
+	// without nulls, empty keys match each other
+	want := []string{"id,city,name,order", "1,Sydney,Rob,o1", "1,Sydney,Rob,o3", ",Darwin,Nobody,o5"}
+	if got := joined(p); !reflect.DeepEqual(got, want) {
+		t.Errorf("Join on two keys = %v, want %v", got, want)
+	}
+
+	if _, err = customers().Join(orders(), []string{"name"}, JoinOptions{}); err == nil {
+		t.Error("Join should fail on a missing key column")
+	}
+	if _, err = customers().Join(orders(), []string{"id"}, JoinOptions{RightKeys: []string{"id", "city"}}); err == nil {
+		t.Error("Join should fail when numbers of key columns are different")
+	}
+	if _, err = customers().Join(orders(), []string{"id"}, JoinOptions{LeftSuffix: "_x", RightSuffix: "_x"}); err == nil {
+		t.Error("Join should fail when suffixes create the same name")
+	}
+
+	// the right id column is not a key, so it is renamed as it clashes with the left key
+	right := &Table{titles: createTitle([]string{"customer", "id"}), rows: [][]string{{"1", "o1"}}}
+	if p, err = customers().Join(right, []string{"id"}, JoinOptions{RightKeys: []string{"customer"}}); err != nil {
+		t.Fatalf("Join failed: %s", err)
+	}
+	if names := p.titles.names(); !reflect.DeepEqual(names, []string{"id", "name", "city", "id_right"}) {
+		t.Errorf("Join titles = %v", names)
+	}
+}
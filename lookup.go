@@ -0,0 +1,114 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MissingKeyPolicy defines what Lookup does with a key not found in the reference Table.
+type MissingKeyPolicy int
+
+const (
+	// MissingEmpty fills the new columns with empty values.
+	MissingEmpty = MissingKeyPolicy(iota)
+	// MissingDefault fills the new columns with LookupOptions.Default.
+	MissingDefault
+	// MissingError fails the lookup.
+	MissingError
+)
+
+// DuplicateKeyPolicy defines which row of the reference Table Lookup uses for a duplicated key.
+type DuplicateKeyPolicy int
+
+const (
+	// DuplicateFirst uses the first row.
+	DuplicateFirst = DuplicateKeyPolicy(iota)
+	// DuplicateLast uses the last row.
+	DuplicateLast
+	// DuplicateError fails the lookup.
+	DuplicateError
+)
+
+// LookupOptions configures Lookup. RefKeys are names of key columns of the reference Table, they are the same
+// as the Table's ones when it is nil.
+type LookupOptions struct {
+	RefKeys    []string
+	Missing    MissingKeyPolicy
+	Default    string
+	Duplicates DuplicateKeyPolicy
+}
+
+// Lookup appends the named columns of the reference Table to the Table by looking up the named key columns,
+// like VLOOKUP of spreadsheets. A key with a null value is missing. The Table is not changed when it fails.
+func (p *Table) Lookup(ref *Table, keys []string, columns []string, opts LookupOptions) error {
+	refNames := opts.RefKeys
+	if refNames == nil {
+		refNames = keys
+	}
+	if len(keys) == 0 || len(keys) != len(refNames) {
+		return fmt.Errorf("failed to execute Lookup method: both sides need the same number of key columns, but have %d and %d", len(keys), len(refNames))
+	}
+	inds, err := p.titles.indexes(keys)
+	if err != nil {
+		return fmt.Errorf("failed to execute Lookup method: %w", err)
+	}
+	refInds, err := ref.titles.indexes(refNames)
+	if err != nil {
+		return fmt.Errorf("failed to execute Lookup method: reference: %w", err)
+	}
+	cols, err := ref.titles.indexes(columns)
+	if err != nil {
+		return fmt.Errorf("failed to execute Lookup method: reference: %w", err)
+	}
+	if err = checkUniqueNames(append(p.titles.names(), columns...)); err != nil {
+		return fmt.Errorf("failed to execute Lookup method: %w", err)
+	}
+
+	refIsNull := ref.nullChecker()
+	index := make(map[string]int, len(ref.rows))
+	for r, row := range ref.rows {
+		if hasNullKey(refIsNull, row, refInds) {
+			continue
+		}
+		k := rowKey(row, refInds)
+		first, exists := index[k]
+		switch {
+		case !exists, opts.Duplicates == DuplicateLast:
+			index[k] = r
+		case opts.Duplicates == DuplicateError:
+			return fmt.Errorf("failed to execute Lookup method: reference rows %d and %d have the same key %s", first, r, strings.Join(cellsAt(row, refInds), ", "))
+		}
+	}
+
+	isNull := p.nullChecker()
+	values := make([][]string, len(p.rows))
+	for r, row := range p.rows {
+		found := -1
+		if !hasNullKey(isNull, row, inds) {
+			if m, ok := index[rowKey(row, inds)]; ok {
+				found = m
+			}
+		}
+		values[r] = make([]string, len(cols))
+		switch {
+		case found >= 0:
+			for i, c := range cols {
+				values[r][i] = ref.rows[found][c]
+			}
+		case opts.Missing == MissingDefault:
+			for i := range cols {
+				values[r][i] = opts.Default
+			}
+		case opts.Missing == MissingError:
+			return fmt.Errorf("failed to execute Lookup method: key %s of row %d is not found", strings.Join(cellsAt(row, inds), ", "), r)
+		}
+	}
+
+	for _, n := range columns {
+		p.titles[n] = len(p.titles)
+	}
+	for r := range p.rows {
+		p.rows[r] = append(p.rows[r], values[r]...)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_Lookup(t *testing.T) {
+	sales := func() *Table {
+		return &Table{
+			titles: createTitle([]string{"order", "country"}),
+			rows: [][]string{
+				{"1", "FR"},
+				{"2", "XX"},
+				{"3", "DE"},
+			},
+		}
+	}
+	countries := &Table{
+		titles: createTitle([]string{"code", "country_name", "currency"}),
+		rows: [][]string{
+			{"FR", "France", "EUR"},
+			{"DE", "Germany", "EUR"},
+			{"FR", "French Republic", "EUR"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts LookupOptions
+		want [][]string
+	}{
+		{
+			name: "Empty and first",
+			opts: LookupOptions{RefKeys: []string{"code"}},
+			want: [][]string{{"1", "FR", "France", "EUR"}, {"2", "XX", "", ""}, {"3", "DE", "Germany", "EUR"}},
+		},
+		{
+			name: "Default and last",
+			opts: LookupOptions{RefKeys: []string{"code"}, Missing: MissingDefault, Default: "?", Duplicates: DuplicateLast},
+			want: [][]string{{"1", "FR", "French Republic", "EUR"}, {"2", "XX", "?", "?"}, {"3", "DE", "Germany", "EUR"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := sales()
+			if err := p.Lookup(countries, []string{"country"}, []string{"country_name", "currency"}, tt.opts); err != nil {
+				t.Fatalf("Lookup failed: %s", err)
+			}
+			if !reflect.DeepEqual(p.rows, tt.want) {
+				t.Errorf("Lookup rows = %v, want %v", p.rows, tt.want)
+			}
+			if p.titles["currency"] != 3 {
+				t.Errorf("Lookup titles = %v", p.titles.names())
+			}
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		p := sales()
+		keys, columns := []string{"country"}, []string{"country_name"}
+		if err := p.Lookup(countries, keys, columns, LookupOptions{RefKeys: []string{"code"}, Missing: MissingError}); err == nil {
+			t.Error("Lookup should fail on a missing key")
+		}
+		if err := p.Lookup(countries, keys, columns, LookupOptions{RefKeys: []string{"code"}, Duplicates: DuplicateError}); err == nil {
+			t.Error("Lookup should fail on a duplicated reference key")
+		}
+		if err := p.Lookup(countries, keys, columns, LookupOptions{}); err == nil {
+			t.Error("Lookup should fail on a key missing in the reference")
+		}
+		if err := p.Lookup(countries, keys, []string{"code"}, LookupOptions{RefKeys: []string{"code"}}); err != nil {
+			t.Errorf("Lookup failed: %s", err)
+		}
+		if err := p.Lookup(countries, keys, []string{"code"}, LookupOptions{RefKeys: []string{"code"}}); err == nil {
+			t.Error("Lookup should fail on an existing column name")
+		}
+		if len(p.titles) != 3 || len(p.rows[0]) != 3 {
+			t.Errorf("Failed lookups should not change the table, but got %v", p.rows)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package csv
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MatchTitles returns names of columns matching the regular expression pattern, in the order of columns.
+func (p *Table) MatchTitles(pattern string) ([]string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute MatchTitles method: %w", err)
+	}
+	var matched []string
+	for _, n := range p.titles.names() {
+		if re.MatchString(n) {
+			matched = append(matched, n)
+		}
+	}
+	return matched, nil
+}
+
+// Melt turns a wide Table into a long one: each value column of a row becomes a new row with identifier columns,
+// a varName column holding the title of the value column and a valueName column holding its value.
+// When values is nil, all columns except identifier columns are value columns. MatchTitles helps to select them
+// by a pattern. The returned Table is independent to its source.
+func (p *Table) Melt(ids, values []string, varName, valueName string) (*Table, error) {
+	idInds, err := p.titles.indexes(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Melt method: %w", err)
+	}
+	if values == nil {
+		isID := make(map[string]bool, len(ids))
+		for _, n := range ids {
+			isID[n] = true
+		}
+		for _, n := range p.titles.names() {
+			if !isID[n] {
+				values = append(values, n)
+			}
+		}
+	}
+	valInds, err := p.titles.indexes(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Melt method: %w", err)
+	}
+
+	titles := append(append([]string{}, ids...), varName, valueName)
+	if err = checkUniqueNames(titles); err != nil {
+		return nil, fmt.Errorf("failed to execute Melt method: %w", err)
+	}
+
+	rows := make([][]string, 0, len(p.rows)*len(valInds))
+	for _, row := range p.rows {
+		for i, v := range valInds {
+			out := make([]string, 0, len(titles))
+			for _, c := range idInds {
+				out = append(out, row[c])
+			}
+			rows = append(rows, append(out, values[i], row[v]))
+		}
+	}
+	return &Table{titles: createTitle(titles), rows: rows}, nil
+}
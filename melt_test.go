@@ -0,0 +1,50 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_Melt(t *testing.T) {
+	p := &Table{
+		titles: createTitle([]string{"region", "2024-01", "2024-02", "note"}),
+		rows: [][]string{
+			{"north", "5", "6", "ok"},
+			{"south", "7", "8", ""},
+		},
+	}
+
+	months, err := p.MatchTitles(`^\d{4}-\d{2}$`)
+	if err != nil || !reflect.DeepEqual(months, []string{"2024-01", "2024-02"}) {
+		t.Fatalf("MatchTitles = %v, %v", months, err)
+	}
+
+	got, err := p.Melt([]string{"region"}, months, "month", "amount")
+	if err != nil {
+		t.Fatalf("Melt failed: %s", err)
+	}
+	if names := got.titles.names(); !reflect.DeepEqual(names, []string{"region", "month", "amount"}) {
+		t.Errorf("Melt titles = %v", names)
+	}
+	want := [][]string{
+		{"north", "2024-01", "5"},
+		{"north", "2024-02", "6"},
+		{"south", "2024-01", "7"},
+		{"south", "2024-02", "8"},
+	}
+	if !reflect.DeepEqual(got.rows, want) {
+		t.Errorf("Melt rows = %v, want %v", got.rows, want)
+	}
+
+	all, _ := p.Melt([]string{"region"}, nil, "variable", "value")
+	if len(all.rows) != 6 {
+		t.Errorf("Melt without value columns should use all other columns, but got %d rows", len(all.rows))
+	}
+
+	if _, err = p.Melt([]string{"region"}, months, "region", "amount"); err == nil {
+		t.Error("Melt should fail when varName clashes with identifier columns")
+	}
+	if _, err = p.MatchTitles(`(`); err == nil {
+		t.Error("MatchTitles should fail on an invalid pattern")
+	}
+}
@@ -0,0 +1,310 @@
+package csv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// RowReader provides rows one by one and returns io.EOF after the last row. *csv.Reader is a RowReader.
+type RowReader interface {
+	Read() ([]string, error)
+}
+
+// tableReader reads rows of a Table as a RowReader.
+type tableReader struct {
+	rows [][]string
+	next int
+}
+
+func (t *tableReader) Read() ([]string, error) {
+	if t.next >= len(t.rows) {
+		return nil, io.EOF
+	}
+	t.next++
+	return t.rows[t.next-1], nil
+}
+
+// compareCells compares two cells of a key column in the way of rowsSorter: nulls are placed by the marker,
+// decimal columns are compared by decimal values, ints by int values and the others as strings.
+// Different to rowsSorter, int detection is done on each pair of cells, so columns should have consistent types.
+func compareCells(p, q string, pNull, qNull, decimal bool, m Marker) int {
+	if pNull || qNull {
+		return compareNulls(pNull, qNull, m.Nulls)
+	}
+	if decimal {
+		a, errA := ParseDecimal(p)
+		b, errB := ParseDecimal(q)
+		if errA == nil && errB == nil {
+			return a.Cmp(b) * int(m.Order)
+		}
+	} else if validInt.MatchString(p) && validInt.MatchString(q) {
+		a, _ := strconv.Atoi(p)
+		b, _ := strconv.Atoi(q)
+		return compare(a, b) * int(m.Order)
+	}
+	return compare(p, q) * int(m.Order)
+}
+
+// mergeSide is one sorted input of a merge join, it reads rows group by group, each group has the same key.
+type mergeSide struct {
+	name   string
+	src    RowReader
+	keys   []int
+	isNull func(col int, v string) bool
+	// peeked is the first row of the next group, nil at the end of input
+	peeked []string
+	row    int
+	done   bool
+}
+
+func (s *mergeSide) read() error {
+	row, err := s.src.Read()
+	if errors.Is(err, io.EOF) {
+		s.peeked, s.done = nil, true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s input: %w", s.name, err)
+	}
+	s.peeked = row
+	s.row++
+	return nil
+}
+
+// merger holds the comparison rules of key columns of both sides.
+type merger struct {
+	markers []Marker
+	decimal []bool
+	// ints tracks int key columns by their positions in markers, as rowsSorter does
+	ints map[int]struct{}
+}
+
+// compare compares the key of row a from side sa with the key of row b from side sb.
+func (m *merger) compare(sa *mergeSide, a []string, sb *mergeSide, b []string) int {
+	for i, mk := range m.markers {
+		p, q := a[sa.keys[i]], b[sb.keys[i]]
+		pNull, qNull := nullAt(sa.isNull, sa.keys[i], p), nullAt(sb.isNull, sb.keys[i], q)
+		if pNull || qNull {
+			if order := compareNulls(pNull, qNull, mk.Nulls); order != 0 {
+				return order
+			}
+			continue
+		}
+		if order := compareValues(p, q, i, m.decimal[i], m.ints) * int(mk.Order); order != 0 {
+			return order
+		}
+	}
+	return 0
+}
+
+// group returns the next group of rows with the same key, nil at the end of input.
+// It fails when the next group has a key less than the current one.
+func (m *merger) group(s *mergeSide) ([][]string, error) {
+	if s.peeked == nil {
+		if s.done {
+			return nil, nil
+		}
+		if err := s.read(); err != nil || s.peeked == nil {
+			return nil, err
+		}
+	}
+	g := [][]string{s.peeked}
+	for {
+		if err := s.read(); err != nil {
+			return nil, err
+		}
+		if s.peeked == nil {
+			return g, nil
+		}
+		order := m.compare(s, g[0], s, s.peeked)
+		if order > 0 {
+			return nil, fmt.Errorf("%s input is not sorted by the markers at row %d", s.name, s.row)
+		}
+		if order < 0 {
+			return g, nil
+		}
+		g = append(g, s.peeked)
+	}
+}
+
+// mergeJoin walks two sorted inputs and emits joined rows in the order of keys.
+func (m *merger) mergeJoin(left, right *mergeSide, l *joinLayout, kind JoinKind, emit func([]string) error) error {
+	keepLeft := kind == LeftJoin || kind == FullJoin
+	keepRight := kind == RightJoin || kind == FullJoin
+
+	unmatched := func(g [][]string, isLeft bool, keep bool) error {
+		if !keep {
+			return nil
+		}
+		for _, row := range g {
+			var err error
+			if isLeft {
+				err = emit(l.row(row, nil))
+			} else {
+				err = emit(l.row(nil, row))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	lg, err := m.group(left)
+	if err != nil {
+		return err
+	}
+	rg, err := m.group(right)
+	if err != nil {
+		return err
+	}
+	for lg != nil || rg != nil {
+		order := 0
+		switch {
+		case rg == nil:
+			order = -1
+		case lg == nil:
+			order = 1
+		default:
+			order = m.compare(left, lg[0], right, rg[0])
+		}
+		// null keys never match
+		if order == 0 && hasNullKey(left.isNull, lg[0], left.keys) {
+			if err = unmatched(lg, true, keepLeft); err != nil {
+				return err
+			}
+			if err = unmatched(rg, false, keepRight); err != nil {
+				return err
+			}
+			if lg, err = m.group(left); err != nil {
+				return err
+			}
+			if rg, err = m.group(right); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch {
+		case order < 0:
+			if err = unmatched(lg, true, keepLeft); err != nil {
+				return err
+			}
+			lg, err = m.group(left)
+		case order > 0:
+			if err = unmatched(rg, false, keepRight); err != nil {
+				return err
+			}
+			rg, err = m.group(right)
+		default:
+			for _, a := range lg {
+				for _, b := range rg {
+					if err = emit(l.row(a, b)); err != nil {
+						return err
+					}
+				}
+			}
+			if lg, err = m.group(left); err != nil {
+				return err
+			}
+			rg, err = m.group(right)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newMerger creates a merger and both sides of a merge join. The names of markers are key columns of the left side.
+func newMerger(left, right *Table, leftRows, rightRows RowReader, markers []NamedMarker, opts JoinOptions) (*merger, *mergeSide, *mergeSide, *joinLayout, error) {
+	if len(markers) == 0 {
+		return nil, nil, nil, nil, fmt.Errorf("no key column")
+	}
+	names := make([]string, len(markers))
+	for i, nm := range markers {
+		names[i] = nm.Name
+	}
+	l, err := newJoinLayout(left, right, names, opts)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	ms, err := left.titles.sortingMarkers(markers)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// key columns are compared as decimals only when both sides agree
+	m := &merger{markers: ms, decimal: make([]bool, len(ms)), ints: make(map[int]struct{})}
+	rightNames := right.titles.names()
+	for i, n := range names {
+		_, leftDecimal := left.decimals[n]
+		_, rightDecimal := right.decimals[rightNames[l.rightKeys[i]]]
+		if leftDecimal != rightDecimal {
+			return nil, nil, nil, nil, fmt.Errorf("key column %s is a decimal column on one side only, set it by SetDecimalColumn on both sides", n)
+		}
+		m.decimal[i] = leftDecimal
+	}
+	return m,
+		&mergeSide{name: "left", src: leftRows, keys: l.leftKeys, isNull: left.nullChecker()},
+		&mergeSide{name: "right", src: rightRows, keys: l.rightKeys, isNull: right.nullChecker()},
+		l, nil
+}
+
+// MergeJoin joins two Tables which have been sorted by markers, e.g. by Table.Sort, without building a hash table.
+// Markers name the key columns of the left Table, JoinOptions.RightKeys names those of the right Table when they
+// are different. Keys can repeat on both sides. Rows are in the order of keys. It fails if any input is not sorted,
+// or a key column is a decimal column of only one of the Tables.
+func (p *Table) MergeJoin(right *Table, markers []NamedMarker, opts JoinOptions) (*Table, error) {
+	m, left, rs, l, err := newMerger(p, right, &tableReader{rows: p.rows}, &tableReader{rows: right.rows}, markers, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute MergeJoin method: %w", err)
+	}
+
+	var rows [][]string
+	err = m.mergeJoin(left, rs, l, opts.Kind, func(row []string) error {
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute MergeJoin method: %w", err)
+	}
+	return &Table{titles: createTitle(l.titles), rows: rows}, nil
+}
+
+// MergeJoinStreams joins two sorted streams and writes the joined rows to w as csv. The first rows of both
+// streams are titles. Only a group of rows with the same key is held in memory for each stream, nulls are not
+// recognised in streams. It fails when a stream is not sorted, rows before the failure have been written.
+func MergeJoinStreams(left, right RowReader, markers []NamedMarker, opts JoinOptions, w io.Writer) error {
+	titles := func(src RowReader, name string) (*Table, error) {
+		names, err := src.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read titles of %s input: %w", name, err)
+		}
+		return &Table{titles: createTitle(names)}, nil
+	}
+	lt, err := titles(left, "left")
+	if err != nil {
+		return err
+	}
+	rt, err := titles(right, "right")
+	if err != nil {
+		return err
+	}
+
+	m, ls, rs, l, err := newMerger(lt, rt, left, right, markers, opts)
+	if err != nil {
+		return fmt.Errorf("failed to execute MergeJoinStreams: %w", err)
+	}
+
+	writer := csv.NewWriter(w)
+	if err = writer.Write(l.titles); err != nil {
+		return err
+	}
+	err = m.mergeJoin(ls, rs, l, opts.Kind, writer.Write)
+	writer.Flush()
+	return errors.Join(err, writer.Error())
+}
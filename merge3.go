@@ -0,0 +1,126 @@
+package csv
+
+import (
+	"fmt"
+)
+
+// ConflictKind defines the kind of a Conflict of Merge3.
+type ConflictKind int
+
+const (
+	// CellConflict is a cell changed differently by both sides, or added differently when the row is not in base.
+	CellConflict = ConflictKind(iota)
+	// DeleteConflict is a row deleted by one side and changed by the other.
+	DeleteConflict
+)
+
+// Conflict is a change Merge3 cannot apply automatically. Column and values are empty for a DeleteConflict.
+type Conflict struct {
+	Kind   ConflictKind
+	Key    []string
+	Column string
+	Base   string
+	Ours   string
+	Theirs string
+}
+
+// Merge3Options configures Merge3. When MarkConflicts is true, conflicting cells are written with markers:
+// <<<<<<< ours ||||||| base ======= theirs >>>>>>>, otherwise they keep the value of ours.
+type Merge3Options struct {
+	MarkConflicts bool
+}
+
+// Merge3 merges the changes of ours and theirs made to base by the named key columns, which should be unique
+// in each Table. All Tables should have the same columns, the result has the columns in the order of base.
+// Rows deleted by one side and not changed by the other are deleted, rows changed by one side and deleted
+// by the other are kept as changed. The result has rows of base first, followed by rows added by ours and then
+// by theirs. Changes made by both sides to the same cells in different ways are returned as Conflicts.
+func Merge3(base, ours, theirs *Table, keys []string, opts Merge3Options) (*Table, []Conflict, error) {
+	inds, err := base.titles.indexes(keys)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute Merge3 function: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, nil, fmt.Errorf("failed to execute Merge3 function: no key column")
+	}
+	// rows of ours and theirs are aligned copies, so they can be used by the result
+	sides := [3][][]string{base.rows}
+	var indexes [3]map[string]int
+	for i, side := range []*Table{ours, theirs} {
+		if sides[i+1], err = base.alignRows(side); err != nil {
+			return nil, nil, fmt.Errorf("failed to execute Merge3 function: %w", err)
+		}
+	}
+	for i, rows := range sides {
+		if indexes[i], err = uniqueKeys(rows, inds); err != nil {
+			return nil, nil, fmt.Errorf("failed to execute Merge3 function: %w", err)
+		}
+	}
+	names := base.titles.names()
+
+	var rows [][]string
+	var conflicts []Conflict
+	// merge merges a row of ours and theirs, b is nil when the row is not in base
+	merge := func(key []string, b, o, t []string) []string {
+		out := make([]string, len(o))
+		for c := range o {
+			var bv string
+			if b != nil {
+				bv = b[c]
+			}
+			switch {
+			case o[c] == t[c], b != nil && t[c] == bv:
+				out[c] = o[c]
+			case b != nil && o[c] == bv:
+				out[c] = t[c]
+			default:
+				conflicts = append(conflicts, Conflict{Kind: CellConflict, Key: key, Column: names[c], Base: bv, Ours: o[c], Theirs: t[c]})
+				out[c] = o[c]
+				if opts.MarkConflicts {
+					out[c] = "<<<<<<< " + o[c] + " ||||||| " + bv + " ======= " + t[c] + " >>>>>>>"
+				}
+			}
+		}
+		return out
+	}
+	equal := func(a, b []string) bool {
+		return rowKey(a, seq(len(a))) == rowKey(b, seq(len(b)))
+	}
+
+	for _, b := range base.rows {
+		k := rowKey(b, inds)
+		key := cellsAt(b, inds)
+		o, inOurs := indexes[1][k]
+		t, inTheirs := indexes[2][k]
+		switch {
+		case inOurs && inTheirs:
+			rows = append(rows, merge(key, b, sides[1][o], sides[2][t]))
+		case inOurs && !equal(sides[1][o], b):
+			conflicts = append(conflicts, Conflict{Kind: DeleteConflict, Key: key})
+			rows = append(rows, sides[1][o])
+		case inTheirs && !equal(sides[2][t], b):
+			conflicts = append(conflicts, Conflict{Kind: DeleteConflict, Key: key})
+			rows = append(rows, sides[2][t])
+		}
+	}
+	for _, o := range sides[1] {
+		k := rowKey(o, inds)
+		if _, inBase := indexes[0][k]; inBase {
+			continue
+		}
+		if t, inTheirs := indexes[2][k]; inTheirs {
+			rows = append(rows, merge(cellsAt(o, inds), nil, o, sides[2][t]))
+		} else {
+			rows = append(rows, o)
+		}
+	}
+	for _, t := range sides[2] {
+		k := rowKey(t, inds)
+		_, inBase := indexes[0][k]
+		_, inOurs := indexes[1][k]
+		if !inBase && !inOurs {
+			rows = append(rows, t)
+		}
+	}
+	return &Table{titles: base.titles.clone(), rows: rows, nulls: base.nulls.clone(), decimals: cloneDecimals(base.decimals)}, conflicts, nil
+}
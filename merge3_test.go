@@ -0,0 +1,76 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMerge3(t *testing.T) {
+	base := &Table{
+		titles: createTitle([]string{"code", "name", "rate"}),
+		rows: [][]string{
+			{"GB", "Britain", "20"},
+			{"FR", "France", "20"},
+			{"DE", "Germany", "19"},
+			{"IT", "Italy", "22"},
+			{"ES", "Spain", "21"},
+		},
+	}
+	ours := &Table{
+		titles: createTitle([]string{"code", "name", "rate"}),
+		rows: [][]string{
+			{"GB", "United Kingdom", "20"},
+			{"FR", "France", "5.5"},
+			{"IT", "Italy", "22"},
+			{"ES", "Spain", "10"},
+			{"NL", "Netherlands", "21"},
+		},
+	}
+	// theirs has columns in another order
+	theirs := &Table{
+		titles: createTitle([]string{"rate", "code", "name"}),
+		rows: [][]string{
+			{"20", "GB", "Britain"},
+			{"10", "FR", "France"},
+			{"19", "DE", "Germany"},
+			{"21", "ES", "Spain"},
+			{"25", "SE", "Sweden"},
+		},
+	}
+
+	got, conflicts, err := Merge3(base, ours, theirs, []string{"code"}, Merge3Options{})
+	if err != nil {
+		t.Fatalf("Merge3 failed: %s", err)
+	}
+	want := [][]string{
+		{"GB", "United Kingdom", "20"},
+		{"FR", "France", "5.5"},
+		{"ES", "Spain", "10"},
+		{"NL", "Netherlands", "21"},
+		{"SE", "Sweden", "25"},
+	}
+	if !reflect.DeepEqual(got.rows, want) {
+		t.Errorf("Merge3 rows = %v, want %v", got.rows, want)
+	}
+	wantConflicts := []Conflict{{Kind: CellConflict, Key: []string{"FR"}, Column: "rate", Base: "20", Ours: "5.5", Theirs: "10"}}
+	if !reflect.DeepEqual(conflicts, wantConflicts) {
+		t.Errorf("Merge3 conflicts = %v, want %v", conflicts, wantConflicts)
+	}
+
+	// Italy is deleted by theirs but changed by ours
+	ours.rows[2][2] = "23"
+	got, conflicts, err = Merge3(base, ours, theirs, []string{"code"}, Merge3Options{MarkConflicts: true})
+	if err != nil {
+		t.Fatalf("Merge3 failed: %s", err)
+	}
+	if got.rows[1][2] != "<<<<<<< 5.5 ||||||| 20 ======= 10 >>>>>>>" {
+		t.Errorf("Merge3 should mark the conflict, but got %s", got.rows[1][2])
+	}
+	if len(conflicts) != 2 || conflicts[1].Kind != DeleteConflict || got.rows[2][0] != "IT" {
+		t.Errorf("Merge3 should keep a changed row deleted by the other side, but got %v %v", conflicts, got.rows)
+	}
+
+	if _, _, err = Merge3(base, ours, &Table{titles: createTitle([]string{"code"})}, []string{"code"}, Merge3Options{}); err == nil {
+		t.Error("Merge3 should fail on different columns")
+	}
+}
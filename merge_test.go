@@ -0,0 +1,104 @@
+package csv
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTable_MergeJoin(t *testing.T) {
+	byID := []NamedMarker{{Name: "id", Order: Ascending}}
+	tests := []struct {
+		name string
+		kind JoinKind
+		want []string
+	}{
+		{"Inner", InnerJoin, []string{
+			"id,name,city_left,order,city_right",
+			"1,Rob,Sydney,o1,Sydney",
+			"1,Rob,Sydney,o3,Sydney",
+			"2,Ken,Melbourne,o2,Hobart",
+		}},
+		{"Full", FullJoin, []string{
+			"id,name,city_left,order,city_right",
+			"1,Rob,Sydney,o1,Sydney",
+			"1,Rob,Sydney,o3,Sydney",
+			"2,Ken,Melbourne,o2,Hobart",
+			"3,Robert,Perth,,",
+			"9,,,o4,Cairns",
+			",Nobody,Darwin,,",
+			",,,o5,Darwin",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right := customers(), orders()
+			for _, p := range []*Table{left, right} {
+				p.SetNulls([]string{""})
+				markers, _ := p.titles.sortingMarkers(byID)
+				p.Sort(markers)
+			}
+
+			p, err := left.MergeJoin(right, byID, JoinOptions{Kind: tt.kind})
+			if err != nil {
+				t.Fatalf("MergeJoin failed: %s", err)
+			}
+			if got := joined(p); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeJoin = \n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+
+	t.Run("Not sorted", func(t *testing.T) {
+		if _, err := customers().MergeJoin(orders(), byID, JoinOptions{}); err == nil {
+			t.Error("MergeJoin should fail on unsorted input")
+		}
+	})
+
+	t.Run("Decimal keys", func(t *testing.T) {
+		byPrice := []NamedMarker{{Name: "price", Order: Ascending}}
+		left := &Table{titles: createTitle([]string{"price", "item"}), rows: [][]string{{"2.5", "a"}, {"10", "b"}}}
+		right := &Table{titles: createTitle([]string{"price", "shop"}), rows: [][]string{{"2.50", "x"}, {"10", "y"}}}
+		if err := right.SetDecimalColumn("price", DecimalFormat{Scale: 2}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := left.MergeJoin(right, byPrice, JoinOptions{}); err == nil {
+			t.Error("MergeJoin should fail on a key column which is a decimal column on one side only")
+		}
+
+		if err := left.SetDecimalColumn("price", DecimalFormat{Scale: 1}); err != nil {
+			t.Fatal(err)
+		}
+		p, err := left.MergeJoin(right, byPrice, JoinOptions{})
+		if err != nil {
+			t.Fatalf("MergeJoin failed: %s", err)
+		}
+		if want := [][]string{{"2.5", "a", "x"}, {"10", "b", "y"}}; !reflect.DeepEqual(p.rows, want) {
+			t.Errorf("MergeJoin rows = %v, want %v", p.rows, want)
+		}
+	})
+}
+
+func TestMergeJoinStreams(t *testing.T) {
+	left := "k,a\n1,x\n1,y\n2,z\n10,w\n"
+	right := "k,b\n1,p\n1,q\n10,r\n11,s\n"
+
+	var w strings.Builder
+	err := MergeJoinStreams(csv.NewReader(strings.NewReader(left)), csv.NewReader(strings.NewReader(right)),
+		[]NamedMarker{{Name: "k", Order: Ascending}}, JoinOptions{Kind: LeftJoin, Fill: "-"}, &w)
+	if err != nil {
+		t.Fatalf("MergeJoinStreams failed: %s", err)
+	}
+	want := "k,a,b\n1,x,p\n1,x,q\n1,y,p\n1,y,q\n2,z,-\n10,w,r\n"
+	if w.String() != want {
+		t.Errorf("MergeJoinStreams wrote\n%s\nwant\n%s", w.String(), want)
+	}
+
+	unsorted := "k,b\n2,p\n1,q\n"
+	err = MergeJoinStreams(csv.NewReader(strings.NewReader(left)), csv.NewReader(strings.NewReader(unsorted)),
+		[]NamedMarker{{Name: "k", Order: Ascending}}, JoinOptions{}, &strings.Builder{})
+	if err == nil || !strings.Contains(err.Error(), "right input is not sorted") {
+		t.Errorf("MergeJoinStreams should report the unsorted right input, but got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package csv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultNulls are the tokens commonly used for missing values.
+var DefaultNulls = []string{"", "NULL", "N/A", "-"}
+
+// nullSet is a set of tokens which represent missing values. Tokens are compared after trimming spaces.
+type nullSet map[string]struct{}
+
+func newNullSet(tokens []string) nullSet {
+	n := make(nullSet, len(tokens))
+	for _, t := range tokens {
+		n[strings.TrimSpace(t)] = struct{}{}
+	}
+	return n
+}
+
+func (n nullSet) has(v string) bool {
+	_, ok := n[strings.TrimSpace(v)]
+	return ok
+}
+
+// tokens returns the tokens of the set in order.
+func (n nullSet) tokens() []string {
+	tokens := make([]string, 0, len(n))
+	for t := range n {
+		tokens = append(tokens, t)
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+// nulls defines null tokens of a Table: a set for all columns and sets for named columns which override the former.
+type nulls struct {
+	table   nullSet
+	columns map[string]nullSet
+}
+
+func (n *nulls) clone() *nulls {
+	if n == nil {
+		return nil
+	}
+	c := &nulls{table: n.table, columns: make(map[string]nullSet, len(n.columns))}
+	for k, v := range n.columns {
+		c.columns[k] = v
+	}
+	return c
+}
+
+// SetNulls sets the tokens treated as nulls in all columns. Calling it with nil removes all null settings,
+// include those set by SetColumnNulls, so no value is a null.
+func (p *Table) SetNulls(tokens []string) {
+	p.changed()
+	if tokens == nil {
+		p.nulls = nil
+		return
+	}
+	if p.nulls == nil {
+		p.nulls = &nulls{columns: make(map[string]nullSet)}
+	}
+	p.nulls.table = newNullSet(tokens)
+}
+
+// SetColumnNulls sets the tokens treated as nulls in the named column, they replace those set by SetNulls for this column.
+func (p *Table) SetColumnNulls(name string, tokens []string) error {
+	if _, exists := p.titles[name]; !exists {
+		return fmt.Errorf("failed to execute SetColumnNulls method: %w", TitleNotFound(name))
+	}
+	if p.nulls == nil {
+		p.nulls = &nulls{columns: make(map[string]nullSet)}
+	}
+	p.nulls.columns[name] = newNullSet(tokens)
+	p.changed()
+	return nil
+}
+
+// columnNulls returns the null tokens of the named column, those set by SetColumnNulls or else by SetNulls.
+// It returns nil when neither is set.
+func (p *Table) columnNulls(name string) []string {
+	if p.nulls == nil {
+		return nil
+	}
+	if set, ok := p.nulls.columns[name]; ok {
+		return set.tokens()
+	}
+	if p.nulls.table != nil {
+		return p.nulls.table.tokens()
+	}
+	return nil
+}
+
+// nullChecker returns a function to check if the value of a column identified by its index is a null.
+// It returns nil when there is no null settings.
+func (p *Table) nullChecker() func(col int, v string) bool {
+	if p.nulls == nil {
+		return nil
+	}
+	byIndex := make(map[int]nullSet, len(p.nulls.columns))
+	for name, set := range p.nulls.columns {
+		if ind, exists := p.titles[name]; exists {
+			byIndex[ind] = set
+		}
+	}
+	table := p.nulls.table
+	return func(col int, v string) bool {
+		if set, ok := byIndex[col]; ok {
+			return set.has(v)
+		}
+		return table.has(v)
+	}
+}
+
+// nullAt is a convenient way to check a cell when there may be no null settings.
+func nullAt(isNull func(col int, v string) bool, col int, v string) bool {
+	return isNull != nil && isNull(col, v)
+}
+
+// IsNull creates an Isfunc which returns true when the named column of a row is a null.
+func (p *Table) IsNull(name string) (Isfunc, error) {
+	ind, exists := p.titles[name]
+	if !exists {
+		return nil, fmt.Errorf("failed to execute IsNull method: %w", TitleNotFound(name))
+	}
+	isNull := p.nullChecker()
+	return func(elems []string) bool {
+		return nullAt(isNull, ind, elems[ind])
+	}, nil
+}
+
+// NotNull creates an Isfunc which returns true when the named column of a row is not a null.
+func (p *Table) NotNull(name string) (Isfunc, error) {
+	is, err := p.IsNull(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute NotNull method: %w", err)
+	}
+	return func(elems []string) bool {
+		return !is(elems)
+	}, nil
+}
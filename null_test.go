@@ -0,0 +1,81 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func withNulls() [][]string {
+	return [][]string{
+		{"gri", "100"},
+		{"ken", "NULL"},
+		{"r", "80"},
+		{"dmr", ""},
+		{"rsc", " N/A "},
+		{"glenda", "150"},
+	}
+}
+
+func TestTable_Sort_nulls(t *testing.T) {
+	tests := []struct {
+		name   string
+		marker Marker
+		want   []string
+	}{
+		{"Ascending, nulls last", Marker{Index: 1, Order: Ascending}, []string{"r", "gri", "glenda", "ken", "dmr", "rsc"}},
+		{"Descending, nulls last", Marker{Index: 1, Order: Descending}, []string{"glenda", "gri", "r", "ken", "dmr", "rsc"}},
+		{"Ascending, nulls first", Marker{Index: 1, Order: Ascending, Nulls: NullsFirst}, []string{"ken", "dmr", "rsc", "r", "gri", "glenda"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Table{titles: createTitle([]string{"user", "scores"}), rows: withNulls()}
+			p.SetNulls(DefaultNulls)
+			p.Sort([]Marker{tt.marker})
+
+			got := make([]string, len(p.rows))
+			for i, r := range p.rows {
+				got[i] = r[0]
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sort with nulls = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_SetColumnNulls(t *testing.T) {
+	p := &Table{titles: createTitle([]string{"user", "scores"}), rows: withNulls()}
+	p.SetNulls(DefaultNulls)
+
+	if err := p.SetColumnNulls("missing", []string{"x"}); err == nil {
+		t.Error("SetColumnNulls should fail on a missing column")
+	}
+
+	// only NULL is a null in scores now
+	if err := p.SetColumnNulls("scores", []string{"NULL"}); err != nil {
+		t.Fatalf("SetColumnNulls failed: %s", err)
+	}
+	isNull, _ := p.IsNull("scores")
+	notNull, _ := p.NotNull("scores")
+
+	nulls := 0
+	for _, r := range p.rows {
+		if isNull(r) {
+			nulls++
+		}
+		if isNull(r) == notNull(r) {
+			t.Errorf("IsNull and NotNull agree on %v", r)
+		}
+	}
+	if nulls != 1 {
+		t.Errorf("Want 1 null in scores, but got %d", nulls)
+	}
+
+	p.SetNulls(nil)
+	isNull, _ = p.IsNull("scores")
+	for _, r := range p.rows {
+		if isNull(r) {
+			t.Errorf("%v should not be a null after removing null settings", r)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// NumberLocale describes how numbers are written: separators, currency symbols, accounting negatives like (123.00)
+// and percentages like 12%.
+type NumberLocale struct {
+	Decimal    rune
+	Grouping   string   // all runes are accepted as grouping separators
+	Currencies []string // symbols or codes, e.g. "€" or "EUR"
+	Accounting bool     // numbers in parentheses are negatives
+	Percent    bool     // 12% is 0.12
+}
+
+var commonCurrencies = []string{"$", "€", "£", "¥", "USD", "EUR", "GBP", "JPY", "CHF"}
+
+// Locale profiles of common number formats.
+var (
+	// LocaleEnglish writes 1,234.56
+	LocaleEnglish = NumberLocale{Decimal: '.', Grouping: ",", Currencies: commonCurrencies, Accounting: true, Percent: true}
+	// LocaleGerman writes 1.234,56
+	LocaleGerman = NumberLocale{Decimal: ',', Grouping: ".", Currencies: commonCurrencies, Accounting: true, Percent: true}
+	// LocaleFrench writes 1 234,56, with a space or a (narrow) no-break space
+	LocaleFrench = NumberLocale{Decimal: ',', Grouping: " \u00a0\u202f", Currencies: commonCurrencies, Accounting: true, Percent: true}
+	// LocaleSwiss writes 1'234.56
+	LocaleSwiss = NumberLocale{Decimal: '.', Grouping: "'\u2019", Currencies: commonCurrencies, Accounting: true, Percent: true}
+)
+
+// Parse converts a number written in the locale to a Decimal. When grouping separators are used, the integer
+// part should have a first group of 1 to 3 digits followed by groups of exactly 3 digits, e.g. 1,234,567.
+func (l NumberLocale) Parse(s string) (Decimal, error) {
+	v := strings.TrimFunc(s, unicode.IsSpace)
+	neg, percent := false, false
+
+	currencies := make([]string, len(l.Currencies))
+	copy(currencies, l.Currencies)
+	// longer symbols first, so US$ is removed before $
+	sort.Slice(currencies, func(i, j int) bool { return len(currencies[i]) > len(currencies[j]) })
+
+	// strip decorations from both ends until nothing changes, so "-€ 45" and "(€45.00)" work
+	for changed := true; changed && v != ""; {
+		before := v
+		if l.Accounting && strings.HasPrefix(v, "(") && strings.HasSuffix(v, ")") {
+			if neg {
+				return Decimal{}, fmt.Errorf("%q has more than one negative sign", s)
+			}
+			neg = true
+			v = v[1 : len(v)-1]
+		}
+		for _, sign := range []string{"-", "\u2212"} {
+			if strings.HasPrefix(v, sign) || strings.HasSuffix(v, sign) {
+				if neg {
+					return Decimal{}, fmt.Errorf("%q has more than one negative sign", s)
+				}
+				neg = true
+				v = strings.TrimSuffix(strings.TrimPrefix(v, sign), sign)
+			}
+		}
+		v = strings.TrimPrefix(v, "+")
+		if l.Percent && (strings.HasSuffix(v, "%") || strings.HasPrefix(v, "%")) {
+			percent = true
+			v = strings.TrimSuffix(strings.TrimPrefix(v, "%"), "%")
+		}
+		for _, c := range currencies {
+			v = strings.TrimSuffix(strings.TrimPrefix(v, c), c)
+		}
+		v = strings.TrimFunc(v, unicode.IsSpace)
+		changed = v != before
+	}
+
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	seenDecimal, lastDigit := false, false
+	// groups of the integer part: the first one has 1 to 3 digits, the others exactly 3
+	grouped, groupDigits := false, 0
+	for _, r := range v {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			lastDigit = true
+			if !seenDecimal {
+				groupDigits++
+			}
+		case r == l.Decimal && !seenDecimal:
+			if grouped && groupDigits != 3 {
+				return Decimal{}, fmt.Errorf("%q has a digit group which is not 3 digits", s)
+			}
+			b.WriteByte('.')
+			seenDecimal, lastDigit = true, false
+		case strings.ContainsRune(l.Grouping, r) && !seenDecimal && lastDigit:
+			if (grouped && groupDigits != 3) || groupDigits > 3 {
+				return Decimal{}, fmt.Errorf("%q has a digit group which is not 3 digits", s)
+			}
+			grouped, groupDigits, lastDigit = true, 0, false
+		default:
+			return Decimal{}, fmt.Errorf("%q is not a number", s)
+		}
+	}
+	if !lastDigit && !seenDecimal {
+		return Decimal{}, fmt.Errorf("%q is not a number", s)
+	}
+	if grouped && !seenDecimal && groupDigits != 3 {
+		return Decimal{}, fmt.Errorf("%q has a digit group which is not 3 digits", s)
+	}
+
+	d, err := ParseDecimal(b.String())
+	if err != nil {
+		return Decimal{}, fmt.Errorf("%q is not a number", s)
+	}
+	if percent {
+		d = Decimal{d.int(), d.scale + 2}
+	}
+	return d, nil
+}
+
+// Compare parses two numbers written in the locale and compares them.
+func (l NumberLocale) Compare(a, b string) (int, error) {
+	p, errA := l.Parse(a)
+	q, errB := l.Parse(b)
+	if err := errors.Join(errA, errB); err != nil {
+		return 0, err
+	}
+	return p.Cmp(q), nil
+}
+
+// NormaliseNumbers rewrites numbers of the named columns written in the locale to plain decimals like -1234.56,
+// and marks the columns as decimal columns so sorting compares them numerically. Nulls are kept.
+// If any value cannot be parsed, nothing is changed.
+func (p *Table) NormaliseNumbers(names []string, l NumberLocale) error {
+	inds, err := p.titles.indexes(names)
+	if err != nil {
+		return fmt.Errorf("failed to execute NormaliseNumbers method: %w", err)
+	}
+
+	isNull := p.nullChecker()
+	parsed := make([][]*Decimal, len(p.rows))
+	scales := make([]int, len(inds))
+	var errs []error
+	for r, row := range p.rows {
+		parsed[r] = make([]*Decimal, len(inds))
+		for i, c := range inds {
+			if nullAt(isNull, c, row[c]) {
+				continue
+			}
+			d, err := l.Parse(row[c])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("row %d, column %s: %w", r, names[i], err))
+				continue
+			}
+			parsed[r][i] = &d
+			if d.scale > scales[i] {
+				scales[i] = d.scale
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to execute NormaliseNumbers method: %w", errors.Join(errs...))
+	}
+
+	for r, row := range p.rows {
+		for i, c := range inds {
+			if d := parsed[r][i]; d != nil {
+				row[c] = d.String()
+			}
+		}
+	}
+	for i, n := range names {
+		if err = p.SetDecimalColumn(n, DecimalFormat{Scale: scales[i]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
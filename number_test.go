@@ -0,0 +1,93 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNumberLocale_Parse(t *testing.T) {
+	tests := []struct {
+		locale NumberLocale
+		in     string
+		want   string
+	}{
+		{LocaleEnglish, "1,234.56", "1234.56"},
+		{LocaleGerman, "1.234,56", "1234.56"},
+		{LocaleFrench, "1 234,56 €", "1234.56"},
+		{LocaleSwiss, "CHF 1'234.50", "1234.50"},
+		{LocaleEnglish, "(123.00)", "-123.00"},
+		{LocaleEnglish, "($1,000)", "-1000"},
+		{LocaleEnglish, "12%", "0.12"},
+		{LocaleGerman, "-12,5 %", "-0.125"},
+		{LocaleEnglish, "€ 45", "45"},
+		{LocaleEnglish, "45-", "-45"},
+		{LocaleEnglish, "+7", "7"},
+		{LocaleEnglish, "1,234,567", "1234567"},
+		{LocaleEnglish, "12,345.678", "12345.678"},
+		{LocaleGerman, "1.234", "1234"},
+	}
+	for _, tt := range tests {
+		d, err := tt.locale.Parse(tt.in)
+		if err != nil || d.String() != tt.want {
+			t.Errorf("Parse(%q) = %s, %v, want %s", tt.in, d, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "abc", "1,,234", ",123", "1.234.56", "-(12)", "12 apples"} {
+		if d, err := LocaleEnglish.Parse(in); err == nil {
+			t.Errorf("Parse(%q) should fail, but got %s", in, d)
+		}
+	}
+	for _, tt := range []struct {
+		locale NumberLocale
+		in     string
+	}{
+		{LocaleEnglish, "1,5"},
+		{LocaleEnglish, "1,23,4"},
+		{LocaleEnglish, "1234,567"},
+		{LocaleEnglish, "1,2345.6"},
+		{LocaleGerman, "1.5"},
+		{LocaleGerman, "1.23,4"},
+	} {
+		if d, err := tt.locale.Parse(tt.in); err == nil {
+			t.Errorf("Parse(%q) should fail on digit groups, but got %s", tt.in, d)
+		}
+	}
+}
+
+func TestTable_NormaliseNumbers(t *testing.T) {
+	p := &Table{
+		titles: createTitle([]string{"vendor", "amount"}),
+		rows: [][]string{
+			{"a", "1.234,56"},
+			{"b", "(12,00)"},
+			{"c", "N/A"},
+			{"d", "€ 45"},
+			{"e", "9,5"},
+		},
+	}
+	p.SetNulls(DefaultNulls)
+
+	if err := p.NormaliseNumbers([]string{"amount"}, LocaleGerman); err != nil {
+		t.Fatalf("NormaliseNumbers failed: %s", err)
+	}
+	p.Sort([]Marker{{Index: 1, Order: Ascending}})
+
+	got := make([]string, len(p.rows))
+	for i, r := range p.rows {
+		got[i] = r[1]
+	}
+	want := []string{"-12.00", "9.5", "45", "1234.56", "N/A"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Normalised and sorted amounts = %v, want %v", got, want)
+	}
+
+	p.rows[0][1] = "twelve"
+	before := p.rows[1][1]
+	if err := p.NormaliseNumbers([]string{"amount"}, LocaleGerman); err == nil {
+		t.Error("NormaliseNumbers should fail on unparsable values")
+	}
+	if p.rows[1][1] != before {
+		t.Error("NormaliseNumbers should not change anything when it fails")
+	}
+}
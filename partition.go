@@ -0,0 +1,212 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// hiveDefaultPartition is the directory value Hive uses for null and empty values, it is read as an empty value.
+const hiveDefaultPartition = "__HIVE_DEFAULT_PARTITION__"
+
+// PartitionOptions configures WritePartitioned. When DropColumns is true, the partition columns are not written
+// into the files as their values are in the directory names. MaxOpenFiles caps the number of files open at
+// the same time, 64 by default.
+type PartitionOptions struct {
+	DropColumns  bool
+	MaxOpenFiles int
+}
+
+// WritePartitioned writes the Table as a Hive-style partitioned dataset under dir: rows are grouped by the named
+// columns without sorting, and each group is written into dir/name1=value1/name2=value2/part-0000.csv. Names and
+// values are escaped as by SplitToFiles. The paths of the written files are returned in the order of the first rows
+// of partitions.
+func (p *Table) WritePartitioned(dir string, names []string, opts PartitionOptions) ([]string, error) {
+	inds, err := p.titles.indexes(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute WritePartitioned method: %w", err)
+	}
+	if len(inds) == 0 {
+		return nil, fmt.Errorf("failed to execute WritePartitioned method: no partition column")
+	}
+
+	kept := seq(len(p.titles))
+	if opts.DropColumns {
+		partition := make(map[int]bool, len(inds))
+		for _, c := range inds {
+			partition[c] = true
+		}
+		kept = kept[:0]
+		for c := 0; c < len(p.titles); c++ {
+			if !partition[c] {
+				kept = append(kept, c)
+			}
+		}
+		if len(kept) == 0 {
+			return nil, fmt.Errorf("failed to execute WritePartitioned method: no column is left after dropping partition columns")
+		}
+	}
+	titles := p.titles.names()
+
+	pool := newFilePool(opts.MaxOpenFiles, cellsAt(titles, kept))
+	paths := make(map[string]string)
+	for _, row := range p.rows {
+		k := rowKey(row, inds)
+		path, ok := paths[k]
+		if !ok {
+			parts := []string{dir}
+			for i, c := range inds {
+				parts = append(parts, escapePathValue(names[i])+"="+escapePathValue(row[c]))
+			}
+			path = filepath.Join(append(parts, "part-0000.csv")...)
+			paths[k] = path
+		}
+		if err = pool.write(path, cellsAt(row, kept)); err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to execute WritePartitioned method: %w", err), pool.close())
+		}
+	}
+	if err = pool.close(); err != nil {
+		return nil, fmt.Errorf("failed to execute WritePartitioned method: %w", err)
+	}
+	return pool.paths, nil
+}
+
+// PartitionFilter decides if a partition is read by ReadPartitioned from its values keyed by partition columns.
+type PartitionFilter func(values map[string]string) bool
+
+// partitionFile is a csv file of a partitioned dataset with the partition values of its directories.
+type partitionFile struct {
+	path   string
+	names  []string
+	values []string
+}
+
+// ReadPartitioned reads a Hive-style partitioned dataset under dir into a Table. The partition columns found
+// in directory names are restored as columns after the columns of the files, unless the files have them.
+// When keep is not nil, partitions it rejects are pruned, their files are not read. All files should have
+// the same columns, and all partitions the same partition columns. Files and directories whose names start
+// with '.', files and directories other than column=value ones whose names start with '_', and files not ending
+// with .csv are skipped.
+func ReadPartitioned(dir string, keep PartitionFilter) (*Table, error) {
+	var files []partitionFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		name := d.Name()
+		// files of other tools like _SUCCESS are skipped, but not partitions like _src=a written by other tools
+		if strings.HasPrefix(name, ".") || (strings.HasPrefix(name, "_") && !(d.IsDir() && strings.Contains(name, "="))) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			if filepath.Ext(name) == ".csv" {
+				pf, err := partitionOf(dir, path)
+				if err != nil {
+					return err
+				}
+				files = append(files, pf)
+			}
+			return nil
+		}
+		if !strings.Contains(name, "=") {
+			return fmt.Errorf("directory %s is not named as column=value", path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute ReadPartitioned function: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("failed to execute ReadPartitioned function: %s has no csv file", dir)
+	}
+
+	partitionNames := files[0].names
+	var p *Table
+	// fileNames are the titles of files, and appended are indexes of partition values appended to rows
+	var fileNames []string
+	var appended []int
+	for _, f := range files {
+		if !slices.Equal(f.names, partitionNames) {
+			return nil, fmt.Errorf("failed to execute ReadPartitioned function: %s has partition columns %v, but %v are expected", f.path, f.names, partitionNames)
+		}
+		if keep != nil {
+			values := make(map[string]string, len(f.names))
+			for i, n := range f.names {
+				values[n] = f.values[i]
+			}
+			if !keep(values) {
+				continue
+			}
+		}
+
+		part, err := loadTable(f.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute ReadPartitioned function: %w", err)
+		}
+		if p == nil {
+			fileNames = part.titles.names()
+			titles := append([]string{}, fileNames...)
+			for i, n := range partitionNames {
+				if _, exists := part.titles[n]; !exists {
+					appended = append(appended, i)
+					titles = append(titles, n)
+				}
+			}
+			p = &Table{titles: createTitle(titles)}
+		}
+		rows, err := (&Table{titles: createTitle(fileNames)}).alignRows(part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute ReadPartitioned function: %s: %w", f.path, err)
+		}
+		for _, row := range rows {
+			for _, i := range appended {
+				row = append(row, f.values[i])
+			}
+			p.rows = append(p.rows, row)
+		}
+	}
+	if p == nil {
+		// all partitions are pruned, the titles are unknown without reading a file
+		return &Table{titles: createTitle(partitionNames)}, nil
+	}
+	return p, nil
+}
+
+// partitionOf parses the partition columns and values from the directories of a file under dir.
+func partitionOf(dir, path string) (partitionFile, error) {
+	pf := partitionFile{path: path}
+	rel, err := filepath.Rel(dir, filepath.Dir(path))
+	if err != nil {
+		return pf, err
+	}
+	if rel == "." {
+		return pf, nil
+	}
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		name, value, found := strings.Cut(part, "=")
+		if !found {
+			return pf, fmt.Errorf("directory %s of %s is not named as column=value", part, path)
+		}
+		if name, err = unescapePathValue(name); err != nil {
+			return pf, err
+		}
+		if value == hiveDefaultPartition {
+			value = ""
+		} else if value, err = unescapePathValue(value); err != nil {
+			return pf, err
+		}
+		pf.names = append(pf.names, name)
+		pf.values = append(pf.values, value)
+	}
+	return pf, nil
+}
@@ -0,0 +1,160 @@
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTable_WritePartitioned(t *testing.T) {
+	events := func() *Table {
+		return &Table{
+			titles: createTitle([]string{"year", "month", "event"}),
+			rows: [][]string{
+				{"2024", "05", "a"},
+				{"2023", "12", "b"},
+				{"2024", "05", "c"},
+				{"2024", "06", "d"},
+				{"2024", "", "e"},
+			},
+		}
+	}
+
+	t.Run("Drop columns", func(t *testing.T) {
+		dir := t.TempDir()
+		paths, err := events().WritePartitioned(dir, []string{"year", "month"}, PartitionOptions{DropColumns: true, MaxOpenFiles: 1})
+		if err != nil {
+			t.Fatalf("WritePartitioned failed: %s", err)
+		}
+		first := filepath.Join(dir, "year=2024", "month=05", "part-0000.csv")
+		if len(paths) != 4 || paths[0] != first {
+			t.Errorf("WritePartitioned paths = %v", paths)
+		}
+		content, err := os.ReadFile(first)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(content); got != "event\na\nc\n" {
+			t.Errorf("WritePartitioned wrote %q", got)
+		}
+		// files of other tools are skipped
+		if err = os.WriteFile(filepath.Join(dir, "_SUCCESS"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ReadPartitioned(dir, nil)
+		if err != nil {
+			t.Fatalf("ReadPartitioned failed: %s", err)
+		}
+		if names := got.titles.names(); !reflect.DeepEqual(names, []string{"event", "year", "month"}) {
+			t.Errorf("ReadPartitioned titles = %v", names)
+		}
+		want := [][]string{
+			{"b", "2023", "12"},
+			{"a", "2024", "05"},
+			{"c", "2024", "05"},
+			{"d", "2024", "06"},
+			{"e", "2024", ""},
+		}
+		if !reflect.DeepEqual(got.rows, want) {
+			t.Errorf("ReadPartitioned rows = %v, want %v", got.rows, want)
+		}
+
+		got, err = ReadPartitioned(dir, func(values map[string]string) bool {
+			return values["year"] == "2024" && values["month"] >= "06"
+		})
+		if err != nil {
+			t.Fatalf("ReadPartitioned failed: %s", err)
+		}
+		if !reflect.DeepEqual(got.rows, [][]string{{"d", "2024", "06"}}) {
+			t.Errorf("ReadPartitioned with pruning rows = %v", got.rows)
+		}
+	})
+
+	t.Run("Keep columns", func(t *testing.T) {
+		dir := t.TempDir()
+		if _, err := events().WritePartitioned(dir, []string{"month"}, PartitionOptions{}); err != nil {
+			t.Fatalf("WritePartitioned failed: %s", err)
+		}
+		got, err := ReadPartitioned(dir, nil)
+		if err != nil {
+			t.Fatalf("ReadPartitioned failed: %s", err)
+		}
+		if names := got.titles.names(); !reflect.DeepEqual(names, []string{"year", "month", "event"}) {
+			t.Errorf("ReadPartitioned should not add partition columns the files have, but titles are %v", names)
+		}
+		if len(got.rows) != 5 {
+			t.Errorf("ReadPartitioned rows = %v", got.rows)
+		}
+	})
+
+	t.Run("Leading underscores", func(t *testing.T) {
+		dir := t.TempDir()
+		p := &Table{
+			titles: createTitle([]string{"_src", "year", "id"}),
+			rows:   [][]string{{"a", "_2024", "1"}, {"_b", "2023", "2"}},
+		}
+		if _, err := p.WritePartitioned(dir, []string{"_src", "year"}, PartitionOptions{DropColumns: true}); err != nil {
+			t.Fatalf("WritePartitioned failed: %s", err)
+		}
+		got, err := ReadPartitioned(dir, nil)
+		if err != nil {
+			t.Fatalf("ReadPartitioned failed: %s", err)
+		}
+		if names := got.titles.names(); !reflect.DeepEqual(names, []string{"id", "_src", "year"}) {
+			t.Errorf("ReadPartitioned titles = %v", names)
+		}
+		// directories are read in the order of their names, %5Fb comes before a
+		if want := [][]string{{"2", "_b", "2023"}, {"1", "a", "_2024"}}; !reflect.DeepEqual(got.rows, want) {
+			t.Errorf("ReadPartitioned rows = %v, want %v", got.rows, want)
+		}
+
+		// partitions of other tools may start with an underscore
+		other := t.TempDir()
+		sub := filepath.Join(other, "_src=a")
+		if err = os.MkdirAll(sub, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(filepath.Join(sub, "part-0000.csv"), []byte("id\n1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if got, err = ReadPartitioned(other, nil); err != nil || !reflect.DeepEqual(got.rows, [][]string{{"1", "a"}}) {
+			t.Errorf("ReadPartitioned = %v, %v", got, err)
+		}
+	})
+
+	t.Run("Hive default partition", func(t *testing.T) {
+		dir := t.TempDir()
+		sub := filepath.Join(dir, "region="+hiveDefaultPartition)
+		if err := os.MkdirAll(sub, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(sub, "part-0000.csv"), []byte("id\n1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReadPartitioned(dir, nil)
+		if err != nil {
+			t.Fatalf("ReadPartitioned failed: %s", err)
+		}
+		if !reflect.DeepEqual(got.rows, [][]string{{"1", ""}}) {
+			t.Errorf("ReadPartitioned rows = %v", got.rows)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		dir := t.TempDir()
+		if _, err := events().WritePartitioned(dir, []string{"missing"}, PartitionOptions{}); err == nil {
+			t.Error("WritePartitioned should fail on a missing column")
+		}
+		if _, err := ReadPartitioned(dir, nil); err == nil {
+			t.Error("ReadPartitioned should fail on a directory without csv files")
+		}
+		if err := os.MkdirAll(filepath.Join(dir, "2024"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadPartitioned(dir, nil); err == nil {
+			t.Error("ReadPartitioned should fail on a directory not named as column=value")
+		}
+	})
+}
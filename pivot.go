@@ -0,0 +1,108 @@
+package csv
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PivotOptions configures Pivot. New columns are in first-seen order unless SortColumns is true,
+// then they are sorted ascending, by numeric values when all of them are numbers as GroupBy parses them, or else
+// as Sort does. Fill is the value of missing combinations.
+type PivotOptions struct {
+	SortColumns bool
+	Fill        string
+	Separator   string // separator of StringJoin
+}
+
+// Pivot creates a cross-tabulation: one row per distinct key of index columns and one new column per distinct value
+// of the columns field. Values of the values field in each cell are aggregated by agg as GroupBy does.
+// Rows with a null in the columns field are skipped. Cells which cannot be parsed as numbers are returned as CellErrors.
+func (p *Table) Pivot(index []string, columns, values string, agg AggregateFunc, opts PivotOptions) (*Table, []CellError, error) {
+	inds, err := p.titles.indexes(index)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute Pivot method: %w", err)
+	}
+	cols, err := p.titles.indexes([]string{columns, values})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute Pivot method: %w", err)
+	}
+	colInd, valInd := cols[0], cols[1]
+	if agg < Count || agg > StringJoin {
+		return nil, nil, fmt.Errorf("failed to execute Pivot method: unknown %s", agg)
+	}
+
+	isNull := p.nullChecker()
+	var names []string
+	position := make(map[string]int)
+	for _, row := range p.rows {
+		v := row[colInd]
+		if _, ok := position[v]; !ok && !nullAt(isNull, colInd, v) {
+			position[v] = len(names)
+			names = append(names, v)
+		}
+	}
+	if opts.SortColumns {
+		numbers := make(map[string]Decimal, len(names))
+		for _, n := range names {
+			d, err := parseNumber(n)
+			if err != nil {
+				numbers = nil
+				break
+			}
+			numbers[n] = d
+		}
+		ascending := Marker{Order: Ascending}
+		sort.SliceStable(names, func(i, j int) bool {
+			if numbers != nil {
+				return numbers[names[i]].Cmp(numbers[names[j]]) < 0
+			}
+			return compareCells(names[i], names[j], false, false, false, ascending) < 0
+		})
+		for i, n := range names {
+			position[n] = i
+		}
+	}
+
+	titles := append(append([]string{}, index...), names...)
+	if err = checkUniqueNames(titles); err != nil {
+		return nil, nil, fmt.Errorf("failed to execute Pivot method: %w", err)
+	}
+
+	a := Aggregate{Column: values, Func: agg, Separator: opts.Separator}
+	var skipped []CellError
+	groups := p.groupRows(inds)
+	rows := make([][]string, len(groups))
+	for g, grp := range groups {
+		cells := make([][]string, len(names))
+		cellRows := make([][]int, len(names))
+		seen := make([]bool, len(names))
+		for _, r := range grp.rows {
+			row := p.rows[r]
+			c, ok := position[row[colInd]]
+			if !ok {
+				continue
+			}
+			seen[c] = true
+			if !nullAt(isNull, valInd, row[valInd]) {
+				cells[c] = append(cells[c], row[valInd])
+				cellRows[c] = append(cellRows[c], r)
+			}
+		}
+
+		out := append([]string{}, grp.key...)
+		for c := range names {
+			if !seen[c] {
+				out = append(out, opts.Fill)
+				continue
+			}
+			v, errs := aggregate(a, cells[c])
+			for _, e := range errs {
+				e.Row, e.Column = cellRows[c][e.Row], values
+				skipped = append(skipped, e)
+			}
+			out = append(out, v)
+		}
+		rows[g] = out
+	}
+	return &Table{titles: createTitle(titles), rows: rows}, skipped, nil
+}
@@ -0,0 +1,81 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func sales() *Table {
+	return &Table{
+		titles: createTitle([]string{"region", "month", "amount"}),
+		rows: [][]string{
+			{"north", "10", "5"},
+			{"south", "2", "7"},
+			{"north", "2", "1.5"},
+			{"north", "10", "2"},
+			{"east", "10", "bad"},
+			{"south", "", "9"},
+		},
+	}
+}
+
+func TestTable_Pivot(t *testing.T) {
+	p := sales()
+	p.SetNulls([]string{""})
+
+	t.Run("First seen", func(t *testing.T) {
+		got, skipped, err := p.Pivot([]string{"region"}, "month", "amount", Sum, PivotOptions{Fill: "0"})
+		if err != nil {
+			t.Fatalf("Pivot failed: %s", err)
+		}
+		if names := got.titles.names(); !reflect.DeepEqual(names, []string{"region", "10", "2"}) {
+			t.Errorf("Pivot titles = %v", names)
+		}
+		want := [][]string{{"north", "7", "1.5"}, {"south", "0", "7"}, {"east", "", "0"}}
+		if !reflect.DeepEqual(got.rows, want) {
+			t.Errorf("Pivot rows = %v, want %v", got.rows, want)
+		}
+		if len(skipped) != 1 || skipped[0].Row != 4 {
+			t.Errorf("Want row 4 reported, but got %v", skipped)
+		}
+	})
+
+	t.Run("Sorted", func(t *testing.T) {
+		got, _, err := p.Pivot([]string{"region"}, "month", "amount", Count, PivotOptions{SortColumns: true, Fill: "-"})
+		if err != nil {
+			t.Fatalf("Pivot failed: %s", err)
+		}
+		if names := got.titles.names(); !reflect.DeepEqual(names, []string{"region", "2", "10"}) {
+			t.Errorf("Pivot titles = %v", names)
+		}
+		want := [][]string{{"north", "1", "2"}, {"south", "1", "-"}, {"east", "-", "1"}}
+		if !reflect.DeepEqual(got.rows, want) {
+			t.Errorf("Pivot rows = %v, want %v", got.rows, want)
+		}
+	})
+
+	t.Run("Sorted numbers", func(t *testing.T) {
+		p := &Table{
+			titles: createTitle([]string{"id", "level", "n"}),
+			rows:   [][]string{{"a", "-5", "1"}, {"a", "10", "1"}, {"a", "2.5", "1"}, {"a", "9", "1"}, {"a", "1,000", "1"}},
+		}
+		got, _, err := p.Pivot([]string{"id"}, "level", "n", Count, PivotOptions{SortColumns: true})
+		if err != nil {
+			t.Fatalf("Pivot failed: %s", err)
+		}
+		if names := got.titles.names(); !reflect.DeepEqual(names, []string{"id", "-5", "2.5", "9", "10", "1,000"}) {
+			t.Errorf("Pivot titles = %v", names)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, _, err := p.Pivot([]string{"region"}, "year", "amount", Sum, PivotOptions{}); err == nil {
+			t.Error("Pivot should fail on a missing column")
+		}
+		p := sales()
+		p.rows[0][1] = "region"
+		if _, _, err := p.Pivot([]string{"region"}, "month", "amount", Sum, PivotOptions{}); err == nil {
+			t.Error("Pivot should fail when a new column clashes with index columns")
+		}
+	})
+}
@@ -1,5 +1,10 @@
 package csv
 
+import (
+	"strconv"
+	"strings"
+)
+
 // biop operates on two elements of a slice marked by indxA and indxB, returns a new slice with original elements and one more of the operational result
 func biop(row []string, indxA, indxB int, operator func(a, b string) string) []string {
 	return append(row, operator(row[indxA], row[indxB]))
@@ -20,3 +25,15 @@ func (o Operation) Do(elems []string) {
 		o.Act(elems)
 	}
 }
+
+// rowKey encodes the cells of a row at the given indexes into a string which can be used as a map key.
+// Each cell is prefixed by its length, so different cells never create the same key.
+func rowKey(row []string, inds []int) string {
+	var b strings.Builder
+	for _, i := range inds {
+		b.WriteString(strconv.Itoa(len(row[i])))
+		b.WriteByte(':')
+		b.WriteString(row[i])
+	}
+	return b.String()
+}
@@ -33,10 +33,20 @@ const (
 	Descending = Direction(-1)
 )
 
+// NullPlacement defines where null values go when sorting, regardless of the Direction of a Marker.
+type NullPlacement int
+
+const (
+	NullsLast = NullPlacement(iota)
+	NullsFirst
+)
+
 // Marker defines a numbered sorting order. It can be applied to a data set of [][]string for sorting.
+// Nulls only has effect when the sorter knows which values are nulls, see Table.SetNulls.
 type Marker struct {
 	Index int
 	Order Direction
+	Nulls NullPlacement
 }
 
 // NamedMarker defines named sorting order. It cannot be applied to a data set of [][]string for sorting.
@@ -45,6 +55,7 @@ type Marker struct {
 type NamedMarker struct {
 	Name  string
 	Order Direction
+	Nulls NullPlacement
 }
 
 // OrderByColumns creates a rowsSorter with a slice of Marker.
@@ -66,6 +77,10 @@ type rowsSorter struct {
 	markers []Marker
 	// a tracker of int columns
 	intColumns map[int]struct{}
+	// isNull reports if the value of a column is a null, nil means there is no null.
+	isNull func(col int, v string) bool
+	// decimalColumns are compared by exact decimal values, values cannot be parsed are compared as strings.
+	decimalColumns map[int]struct{}
 }
 
 // Len is part of sort.Interface.
@@ -86,31 +101,12 @@ func (byCols *rowsSorter) Swap(i, j int) {
 func (byCols *rowsSorter) Less(i, j int) bool {
 	// all markers need to be less
 	// fmt.Println(rows[i], "vs", rows[j])
-	var order, marker int
+	var order int
 	nMarker := len(byCols.markers)
 
 	// Check first markers, if i equals to j on the marker, continue to the next marker
 	for m := 0; m < nMarker; m++ {
-		marker = byCols.markers[m].Index
-
-		// fmt.Printf("Compare marker %d, check %s < %s\n", marker, rows[i][marker], rows[j][marker])
-		if _, exists := byCols.intColumns[marker]; exists {
-			// fmt.Printf("%d has been checked before\n", marker)
-			p, _ := strconv.Atoi(byCols.rows[i][marker])
-			q, _ := strconv.Atoi(byCols.rows[j][marker])
-			order = compare(p, q)
-		} else if validInt.MatchString(byCols.rows[i][marker]) && validInt.MatchString(byCols.rows[j][marker]) {
-			// fmt.Printf("Adding %d as int column\n", marker)
-			byCols.intColumns[marker] = struct{}{}
-			p, _ := strconv.Atoi(byCols.rows[i][marker])
-			q, _ := strconv.Atoi(byCols.rows[j][marker])
-			order = compare(p, q)
-		} else {
-			order = compare(byCols.rows[i][marker], byCols.rows[j][marker])
-		}
-
-		// apply ordering
-		order = order * int(byCols.markers[m].Order)
+		order = byCols.compareColumn(i, j, byCols.markers[m])
 
 		if order != 0 && m < nMarker-1 {
 			switch order {
@@ -124,6 +120,68 @@ func (byCols *rowsSorter) Less(i, j int) bool {
 	return order == -1
 }
 
+// compareColumn compares row i and row j on the marked column and applies the ordering of the marker.
+// Nulls are placed by the marker's Nulls and they do not take part in int column detection.
+func (byCols *rowsSorter) compareColumn(i, j int, m Marker) int {
+	var order int
+	marker := m.Index
+
+	if byCols.isNull != nil {
+		pNull := byCols.isNull(marker, byCols.rows[i][marker])
+		qNull := byCols.isNull(marker, byCols.rows[j][marker])
+		if pNull || qNull {
+			return compareNulls(pNull, qNull, m.Nulls)
+		}
+	}
+
+	_, decimal := byCols.decimalColumns[marker]
+	order = compareValues(byCols.rows[i][marker], byCols.rows[j][marker], marker, decimal, byCols.intColumns)
+
+	// apply ordering
+	return order * int(m.Order)
+}
+
+// compareValues compares two non-null values of column col in ascending order. Values of a decimal column are
+// compared by decimal values when both can be parsed. A column is tracked in ints once two of its values are ints,
+// then all its values are compared by int values. The others are compared as strings.
+func compareValues(p, q string, col int, decimal bool, ints map[int]struct{}) int {
+	if decimal {
+		a, errA := ParseDecimal(p)
+		b, errB := ParseDecimal(q)
+		if errA == nil && errB == nil {
+			return a.Cmp(b)
+		}
+		return compare(p, q)
+	}
+
+	if _, exists := ints[col]; exists {
+		a, _ := strconv.Atoi(p)
+		b, _ := strconv.Atoi(q)
+		return compare(a, b)
+	} else if validInt.MatchString(p) && validInt.MatchString(q) {
+		ints[col] = struct{}{}
+		a, _ := strconv.Atoi(p)
+		b, _ := strconv.Atoi(q)
+		return compare(a, b)
+	}
+	return compare(p, q)
+}
+
+// compareNulls orders two values of which at least one is null.
+func compareNulls(pNull, qNull bool, placement NullPlacement) int {
+	if pNull && qNull {
+		return 0
+	}
+	order := 1
+	if pNull {
+		order = -1
+	}
+	if placement == NullsLast {
+		order = -order
+	}
+	return order
+}
+
 // Sort sorts the argument slice according to the less functions passed to OrderedBy.
 func (byCols *rowsSorter) Sort(rows [][]string) {
 	byCols.rows = rows
@@ -0,0 +1,143 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Normalisation defines how key values are normalised before they are compared, options can be combined.
+type Normalisation int
+
+const (
+	// TrimSpace removes leading and trailing spaces.
+	TrimSpace = Normalisation(1 << iota)
+	// FoldCase compares values case-insensitively.
+	FoldCase
+)
+
+func (n Normalisation) apply(v string) string {
+	if n&TrimSpace != 0 {
+		v = strings.TrimSpace(v)
+	}
+	if n&FoldCase != 0 {
+		v = strings.ToLower(v)
+	}
+	return v
+}
+
+// KeySet is a set of keys made of one or more columns. Its normalisation is applied to both keys in the set and keys checked against it.
+type KeySet struct {
+	keys      map[string]struct{}
+	width     int
+	normalise Normalisation
+}
+
+// normalisedKey creates a key from cells of a row at inds after normalisation.
+func (k *KeySet) normalisedKey(row []string, inds []int) string {
+	cells := make([]string, len(inds))
+	for i, c := range inds {
+		cells[i] = k.normalise.apply(row[c])
+	}
+	return rowKey(cells, seq(len(cells)))
+}
+
+// seq returns 0, 1, ... n-1.
+func seq(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+// Len returns the number of distinct keys.
+func (k *KeySet) Len() int {
+	return len(k.keys)
+}
+
+// Contains reports if the key made of values is in the set.
+func (k *KeySet) Contains(values ...string) bool {
+	if len(values) != k.width {
+		return false
+	}
+	_, ok := k.keys[k.normalisedKey(values, seq(len(values)))]
+	return ok
+}
+
+// KeySet collects keys of the named columns. Rows with a null key are skipped.
+func (p *Table) KeySet(names []string, n Normalisation) (*KeySet, error) {
+	inds, err := p.titles.indexes(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute KeySet method: %w", err)
+	}
+	k := &KeySet{keys: make(map[string]struct{}, len(p.rows)), width: len(inds), normalise: n}
+	isNull := p.nullChecker()
+	for _, row := range p.rows {
+		if !hasNullKey(isNull, row, inds) {
+			k.keys[k.normalisedKey(row, inds)] = struct{}{}
+		}
+	}
+	return k, nil
+}
+
+// LoadKeySet loads a csv file named by fileName and collects keys of the named columns.
+func LoadKeySet(fileName string, names []string, n Normalisation) (*KeySet, error) {
+	p, err := LoadTable(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return p.KeySet(names, n)
+}
+
+// keepByKeys keeps rows whose key presence in keys equals to present. This a in place procedure like Filter.
+func (p *Table) keepByKeys(keys *KeySet, names []string, present bool) (matched, unmatched int, err error) {
+	inds, err := p.titles.indexes(names)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(inds) != keys.width {
+		return 0, 0, fmt.Errorf("key set has %d columns, but %d names are given", keys.width, len(inds))
+	}
+
+	isNull := p.nullChecker()
+	temp := p.rows[:0]
+	for _, row := range p.rows {
+		found := false
+		if !hasNullKey(isNull, row, inds) {
+			_, found = keys.keys[keys.normalisedKey(row, inds)]
+		}
+		if found {
+			matched++
+		} else {
+			unmatched++
+		}
+		if found == present {
+			temp = append(temp, row)
+		}
+	}
+	p.rows = temp
+	p.changed()
+	return matched, unmatched, nil
+}
+
+// SemiJoin keeps rows whose keys of the named columns are in keys and removes the others.
+// It returns the numbers of matched and unmatched rows. Rows with a null key never match.
+// This a in place procedure: p.rows are replaced.
+func (p *Table) SemiJoin(keys *KeySet, names []string) (matched, unmatched int, err error) {
+	matched, unmatched, err = p.keepByKeys(keys, names, true)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to execute SemiJoin method: %w", err)
+	}
+	return matched, unmatched, nil
+}
+
+// AntiJoin removes rows whose keys of the named columns are in keys and keeps the others.
+// It returns the numbers of matched and unmatched rows. Rows with a null key never match, so they are kept.
+// This a in place procedure: p.rows are replaced.
+func (p *Table) AntiJoin(keys *KeySet, names []string) (matched, unmatched int, err error) {
+	matched, unmatched, err = p.keepByKeys(keys, names, false)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to execute AntiJoin method: %w", err)
+	}
+	return matched, unmatched, nil
+}
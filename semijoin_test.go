@@ -0,0 +1,69 @@
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTable_SemiJoin(t *testing.T) {
+	reference := &Table{
+		titles: createTitle([]string{"user", "sub"}),
+		rows:   [][]string{{" GRI ", "go"}, {"Ken", "C"}, {"", "Go"}},
+	}
+	reference.SetNulls([]string{""})
+
+	t.Run("Exact", func(t *testing.T) {
+		keys, _ := reference.KeySet([]string{"user", "sub"}, 0)
+		p := &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
+		matched, unmatched, err := p.SemiJoin(keys, []string{"user", "sub"})
+		if err != nil || matched != 0 || unmatched != 9 || len(p.rows) != 0 {
+			t.Errorf("SemiJoin = %d, %d, %v with %d rows, want 0, 9, nil with 0 rows", matched, unmatched, err, len(p.rows))
+		}
+	})
+
+	t.Run("Normalised", func(t *testing.T) {
+		keys, _ := reference.KeySet([]string{"user", "sub"}, TrimSpace|FoldCase)
+		if keys.Len() != 2 || !keys.Contains("gri", "GO") {
+			t.Errorf("KeySet should have 2 normalised keys, but has %d", keys.Len())
+		}
+		p := &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
+		matched, unmatched, err := p.SemiJoin(keys, []string{"user", "sub"})
+		if err != nil || matched != 2 || unmatched != 7 || len(p.rows) != 2 {
+			t.Errorf("SemiJoin = %d, %d, %v with %d rows, want 2, 7, nil with 2 rows", matched, unmatched, err, len(p.rows))
+		}
+
+		p = &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
+		matched, unmatched, err = p.AntiJoin(keys, []string{"user", "sub"})
+		if err != nil || matched != 2 || unmatched != 7 || len(p.rows) != 7 {
+			t.Errorf("AntiJoin = %d, %d, %v with %d rows, want 2, 7, nil with 7 rows", matched, unmatched, err, len(p.rows))
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		keys, _ := reference.KeySet([]string{"user"}, 0)
+		p := &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
+		if _, _, err := p.SemiJoin(keys, []string{"user", "sub"}); err == nil {
+			t.Error("SemiJoin should fail when numbers of key columns are different")
+		}
+		if _, _, err := p.AntiJoin(keys, []string{"name"}); err == nil {
+			t.Error("AntiJoin should fail on a missing column")
+		}
+	})
+}
+
+func TestLoadKeySet(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "keys.csv")
+	if err := os.WriteFile(fileName, []byte("user\nken\nrsc\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := LoadKeySet(fileName, []string{"user"}, 0)
+	if err != nil {
+		t.Fatalf("LoadKeySet failed: %s", err)
+	}
+
+	p := &Table{titles: createTitle([]string{"user", "sub", "scores"}), rows: numbersAsStrings()}
+	if matched, _, _ := p.AntiJoin(keys, []string{"user"}); matched != 3 || len(p.rows) != 6 {
+		t.Errorf("AntiJoin matched %d and kept %d rows, want 3 and 6", matched, len(p.rows))
+	}
+}
@@ -0,0 +1,115 @@
+package csv
+
+import (
+	"fmt"
+)
+
+// alignRows returns rows of other with columns in the order of p's titles. Both Tables should have
+// the same column names, otherwise an error returns.
+func (p *Table) alignRows(other *Table) ([][]string, error) {
+	if len(p.titles) != len(other.titles) {
+		return nil, fmt.Errorf("tables have different numbers of columns: %d and %d", len(p.titles), len(other.titles))
+	}
+	inds, err := other.titles.indexes(p.titles.names())
+	if err != nil {
+		return nil, err
+	}
+
+	aligned := make([][]string, len(other.rows))
+	for r, row := range other.rows {
+		aligned[r] = make([]string, len(inds))
+		for i, c := range inds {
+			aligned[r][i] = row[c]
+		}
+	}
+	return aligned, nil
+}
+
+// keysOf returns the set of keys of whole rows.
+func keysOf(rows [][]string) map[string]struct{} {
+	keys := make(map[string]struct{}, len(rows))
+	for _, row := range rows {
+		keys[rowKey(row, seq(len(row)))] = struct{}{}
+	}
+	return keys
+}
+
+// setOperation creates a new Table of p's titles from rows of both sides which are kept by keep.
+// When distinct is true, a row is kept once.
+func (p *Table) setOperation(other *Table, distinct bool, keep func(key string, fromOther bool) bool) (*Table, error) {
+	aligned, err := p.alignRows(other)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	var rows [][]string
+	add := func(source [][]string, fromOther bool) {
+		for _, row := range source {
+			k := rowKey(row, seq(len(row)))
+			if !keep(k, fromOther) {
+				continue
+			}
+			if distinct {
+				if _, ok := seen[k]; ok {
+					continue
+				}
+				seen[k] = struct{}{}
+			}
+			c := make([]string, len(row))
+			copy(c, row)
+			rows = append(rows, c)
+		}
+	}
+	add(p.rows, false)
+	add(aligned, true)
+
+	return &Table{titles: p.titles.clone(), rows: rows, nulls: p.nulls.clone(), decimals: cloneDecimals(p.decimals)}, nil
+}
+
+// Union creates a new Table with rows of both Tables, rows of other are aligned to p by titles.
+// When all is false, duplicated rows are removed as Unique does, otherwise all rows are kept.
+// The returned Table is independent to its sources.
+func (p *Table) Union(other *Table, all bool) (*Table, error) {
+	np, err := p.setOperation(other, !all, func(string, bool) bool { return true })
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Union method: %w", err)
+	}
+	return np, nil
+}
+
+// Intersect creates a new Table with distinct rows which are in both Tables, aligned by titles.
+// The returned Table is independent to its sources.
+func (p *Table) Intersect(other *Table) (*Table, error) {
+	aligned, err := p.alignRows(other)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Intersect method: %w", err)
+	}
+	keys := keysOf(aligned)
+	np, err := p.setOperation(&Table{titles: p.titles}, true, func(k string, _ bool) bool {
+		_, ok := keys[k]
+		return ok
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Intersect method: %w", err)
+	}
+	return np, nil
+}
+
+// Except creates a new Table with distinct rows of p which are not in other, aligned by titles.
+// The returned Table is independent to its sources.
+func (p *Table) Except(other *Table) (*Table, error) {
+	aligned, err := p.alignRows(other)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Except method: %w", err)
+	}
+	keys := keysOf(aligned)
+	np, err := p.setOperation(&Table{titles: p.titles}, true, func(k string, _ bool) bool {
+		_, ok := keys[k]
+		return !ok
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Except method: %w", err)
+	}
+	return np, nil
+}
@@ -0,0 +1,70 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func setSources() (*Table, *Table) {
+	a := &Table{
+		titles: createTitle([]string{"user", "sub"}),
+		rows:   [][]string{{"gri", "Go"}, {"ken", "C"}, {"gri", "Go"}, {"r", "C"}},
+	}
+	// same columns in a different order
+	b := &Table{
+		titles: createTitle([]string{"sub", "user"}),
+		rows:   [][]string{{"C", "ken"}, {"Go", "rsc"}, {"C", "ken"}},
+	}
+	return a, b
+}
+
+func TestTable_setOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(a, b *Table) (*Table, error)
+		want [][]string
+	}{
+		{"Union", func(a, b *Table) (*Table, error) { return a.Union(b, false) },
+			[][]string{{"gri", "Go"}, {"ken", "C"}, {"r", "C"}, {"rsc", "Go"}}},
+		{"Union all", func(a, b *Table) (*Table, error) { return a.Union(b, true) },
+			[][]string{{"gri", "Go"}, {"ken", "C"}, {"gri", "Go"}, {"r", "C"}, {"ken", "C"}, {"rsc", "Go"}, {"ken", "C"}}},
+		{"Intersect", func(a, b *Table) (*Table, error) { return a.Intersect(b) },
+			[][]string{{"ken", "C"}}},
+		{"Except", func(a, b *Table) (*Table, error) { return a.Except(b) },
+			[][]string{{"gri", "Go"}, {"r", "C"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := setSources()
+			got, err := tt.op(a, b)
+			if err != nil {
+				t.Fatalf("%s failed: %s", tt.name, err)
+			}
+			if !reflect.DeepEqual(got.rows, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got.rows, tt.want)
+			}
+			got.rows[0][0] = "corrupted"
+			if a.rows[0][0] == "corrupted" || b.rows[0][1] == "corrupted" {
+				t.Errorf("%s should create an independent Table", tt.name)
+			}
+		})
+	}
+}
+
+func TestTable_setOperations_incompatible(t *testing.T) {
+	a, _ := setSources()
+	for _, other := range []*Table{
+		{titles: createTitle([]string{"user"})},
+		{titles: createTitle([]string{"user", "language"})},
+	} {
+		if _, err := a.Union(other, true); err == nil {
+			t.Errorf("Union should fail with titles %v", other.titles.names())
+		}
+		if _, err := a.Intersect(other); err == nil {
+			t.Errorf("Intersect should fail with titles %v", other.titles.names())
+		}
+		if _, err := a.Except(other); err == nil {
+			t.Errorf("Except should fail with titles %v", other.titles.names())
+		}
+	}
+}
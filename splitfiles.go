@@ -0,0 +1,209 @@
+package csv
+
+import (
+	"container/list"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// defaultMaxOpenFiles is the number of files SplitToFiles keeps open when SplitFilesOptions.MaxOpenFiles is not set.
+const defaultMaxOpenFiles = 64
+
+// emptyPathValue replaces an empty value in a file name, a leading underscore of other values is escaped.
+const emptyPathValue = "_"
+
+// escapePathValue makes a value safe as a part of a file name by percent-encoding path separators, characters
+// which are not allowed by common filesystems, control characters, '%' and '=', and a leading '.' or '_',
+// as tools skip files and directories starting with them.
+func escapePathValue(v string) string {
+	if v == "" {
+		return emptyPathValue
+	}
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		if c < 0x20 || c == 0x7f || strings.IndexByte(`%/\:*?"<>|=`, c) >= 0 || (i == 0 && (c == '.' || c == '_')) {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// unescapePathValue reverses escapePathValue.
+func unescapePathValue(v string) (string, error) {
+	if v == emptyPathValue {
+		return "", nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		if v[i] != '%' {
+			b.WriteByte(v[i])
+			continue
+		}
+		if i+2 >= len(v) {
+			return "", fmt.Errorf("%q has an incomplete escape", v)
+		}
+		c, err := strconv.ParseUint(v[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", fmt.Errorf("%q has an invalid escape", v)
+		}
+		b.WriteByte(byte(c))
+		i += 2
+	}
+	return b.String(), nil
+}
+
+// filePool writes rows into csv files keeping at most max files open. The least recently used file is closed
+// when another one is needed, and reopened for appending later. A file gets the header when it is created.
+type filePool struct {
+	max     int
+	header  []string
+	open    map[string]*list.Element
+	lru     *list.List
+	created map[string]bool
+	// paths are the created files in the order of creation
+	paths []string
+}
+
+type pooledFile struct {
+	path string
+	f    *os.File
+	w    *csv.Writer
+}
+
+func newFilePool(max int, header []string) *filePool {
+	if max <= 0 {
+		max = defaultMaxOpenFiles
+	}
+	return &filePool{max: max, header: header, open: make(map[string]*list.Element), lru: list.New(), created: make(map[string]bool)}
+}
+
+// write writes a row into the file named by path, its directory is created when it does not exist.
+func (fp *filePool) write(path string, row []string) error {
+	if e, ok := fp.open[path]; ok {
+		fp.lru.MoveToFront(e)
+		return e.Value.(*pooledFile).w.Write(row)
+	}
+	if fp.lru.Len() >= fp.max {
+		if err := fp.closeFile(fp.lru.Back()); err != nil {
+			return err
+		}
+	}
+
+	flag := os.O_WRONLY | os.O_APPEND
+	if !fp.created[path] {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(path, flag, 0o644)
+	if err != nil {
+		return err
+	}
+	pf := &pooledFile{path: path, f: f, w: csv.NewWriter(f)}
+	fp.open[path] = fp.lru.PushFront(pf)
+	if !fp.created[path] {
+		fp.created[path] = true
+		fp.paths = append(fp.paths, path)
+		if len(fp.header) > 0 {
+			if err = pf.w.Write(fp.header); err != nil {
+				return err
+			}
+		}
+	}
+	return pf.w.Write(row)
+}
+
+func (fp *filePool) closeFile(e *list.Element) error {
+	pf := fp.lru.Remove(e).(*pooledFile)
+	delete(fp.open, pf.path)
+	pf.w.Flush()
+	return errors.Join(pf.w.Error(), pf.f.Close())
+}
+
+// close flushes and closes all open files.
+func (fp *filePool) close() error {
+	var errs []error
+	for fp.lru.Len() > 0 {
+		errs = append(errs, fp.closeFile(fp.lru.Back()))
+	}
+	return errors.Join(errs...)
+}
+
+// SplitFilesOptions configures SplitToFiles. MaxOpenFiles caps the number of files open at the same time,
+// 64 by default.
+type SplitFilesOptions struct {
+	MaxOpenFiles int
+}
+
+var placeholder = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// SplitToFiles groups rows by the named columns and writes each group with the titles into a csv file under dir.
+// Rows need no sorting and keep their order in the files. File names come from template, in which {name} is
+// replaced by the escaped value of the named column, e.g. {region}/{year}.csv; all placeholders should be named
+// columns and a template without {name} of some named column would merge groups. An empty value becomes _ and
+// characters which are unsafe in file names are percent-encoded, so values cannot escape dir.
+// The paths of the written files are returned in the order of the first rows of groups.
+func (p *Table) SplitToFiles(dir, template string, names []string, opts SplitFilesOptions) ([]string, error) {
+	inds, err := p.titles.indexes(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute SplitToFiles method: %w", err)
+	}
+	positions := make(map[string]int, len(names))
+	for i, n := range names {
+		positions[n] = i
+	}
+	for _, m := range placeholder.FindAllStringSubmatch(template, -1) {
+		if _, ok := positions[m[1]]; !ok {
+			return nil, fmt.Errorf("failed to execute SplitToFiles method: placeholder {%s} is not a named column", m[1])
+		}
+	}
+	if strings.ContainsAny(placeholder.ReplaceAllString(template, ""), "{}") {
+		return nil, fmt.Errorf("failed to execute SplitToFiles method: template %q has unbalanced braces", template)
+	}
+
+	pool := newFilePool(opts.MaxOpenFiles, p.titles.names())
+	paths := make(map[string]string)
+	for _, row := range p.rows {
+		k := rowKey(row, inds)
+		path, ok := paths[k]
+		if !ok {
+			name := placeholder.ReplaceAllStringFunc(template, func(m string) string {
+				return escapePathValue(row[inds[positions[m[1:len(m)-1]]]])
+			})
+			if path, err = filePath(dir, name); err != nil {
+				return nil, errors.Join(fmt.Errorf("failed to execute SplitToFiles method: %w", err), pool.close())
+			}
+			paths[k] = path
+		}
+		if err = pool.write(path, row); err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to execute SplitToFiles method: %w", err), pool.close())
+		}
+	}
+	if err = pool.close(); err != nil {
+		return nil, fmt.Errorf("failed to execute SplitToFiles method: %w", err)
+	}
+	return pool.paths, nil
+}
+
+// filePath joins dir and a relative file name, the name should not lead out of dir.
+func filePath(dir, name string) (string, error) {
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("file name %q should be relative", name)
+	}
+	path := filepath.Join(dir, name)
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("file name %q is not inside %s", name, dir)
+	}
+	return path, nil
+}
@@ -0,0 +1,84 @@
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_escapePathValue(t *testing.T) {
+	for _, v := range []string{"", "_", "_src", "north", "a/b", `..\x`, "50%", "k=v", ".hidden", "tab\there", "naïve"} {
+		e := escapePathValue(v)
+		if e == "" || filepath.Base(e) != e || e[0] == '.' || (e[0] == '_' && v != "") {
+			t.Errorf("escapePathValue(%q) = %q is not safe", v, e)
+		}
+		if u, err := unescapePathValue(e); err != nil || u != v {
+			t.Errorf("unescapePathValue(%q) = %q, %v, want %q", e, u, err, v)
+		}
+	}
+	if _, err := unescapePathValue("50%2"); err == nil {
+		t.Error("unescapePathValue should fail on an incomplete escape")
+	}
+}
+
+func TestTable_SplitToFiles(t *testing.T) {
+	sales := &Table{
+		titles: createTitle([]string{"region", "year", "amount"}),
+		rows: [][]string{
+			{"north", "2023", "1"},
+			{"south/east", "2023", "2"},
+			{"north", "2024", "3"},
+			{"north", "2023", "4"},
+			{"", "2024", "5"},
+			{"south/east", "2023", "6"},
+		},
+	}
+
+	for _, max := range []int{0, 1} {
+		dir := t.TempDir()
+		paths, err := sales.SplitToFiles(dir, "{region}/{year}.csv", []string{"region", "year"}, SplitFilesOptions{MaxOpenFiles: max})
+		if err != nil {
+			t.Fatalf("SplitToFiles failed: %s", err)
+		}
+		want := []string{
+			filepath.Join(dir, "north", "2023.csv"),
+			filepath.Join(dir, "south%2Feast", "2023.csv"),
+			filepath.Join(dir, "north", "2024.csv"),
+			filepath.Join(dir, "_", "2024.csv"),
+		}
+		if !reflect.DeepEqual(paths, want) {
+			t.Errorf("SplitToFiles with %d open files paths = %v, want %v", max, paths, want)
+		}
+		content, err := os.ReadFile(want[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(content); got != "region,year,amount\nnorth,2023,1\nnorth,2023,4\n" {
+			t.Errorf("SplitToFiles with %d open files wrote %q", max, got)
+		}
+		loaded, err := LoadTable(want[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(loaded.rows, [][]string{{"south/east", "2023", "2"}, {"south/east", "2023", "6"}}) {
+			t.Errorf("SplitToFiles with %d open files wrote %v", max, loaded.rows)
+		}
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		dir := t.TempDir()
+		if _, err := sales.SplitToFiles(dir, "{region}/{month}.csv", []string{"region"}, SplitFilesOptions{}); err == nil {
+			t.Error("SplitToFiles should fail on a placeholder which is not a named column")
+		}
+		if _, err := sales.SplitToFiles(dir, "{region.csv", []string{"region"}, SplitFilesOptions{}); err == nil {
+			t.Error("SplitToFiles should fail on unbalanced braces")
+		}
+		if _, err := sales.SplitToFiles(dir, "../{region}.csv", []string{"region"}, SplitFilesOptions{}); err == nil {
+			t.Error("SplitToFiles should fail on a template leading out of dir")
+		}
+		if _, err := sales.SplitToFiles(dir, "{region}.csv", []string{"missing"}, SplitFilesOptions{}); err == nil {
+			t.Error("SplitToFiles should fail on a missing column")
+		}
+	})
+}
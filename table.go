@@ -16,24 +16,50 @@ import (
 func NewTable(fileName string) *Table {
 	fmt.Println("We will be process csv = ", fileName)
 
-	file, err := os.Open(fileName)
-
+	p, err := LoadTable(fileName)
 	if err != nil {
 		log.Fatal(err)
 	}
+	return p
+}
+
+// LoadTable opens a csv file named by fileName and returns *Table. Different to NewTable,
+// it returns errors to the caller. If a CSVW metadata document describes the file, it is applied,
+// see DiscoverMetadata.
+func LoadTable(fileName string) (*Table, error) {
+	meta, err := DiscoverMetadata(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if meta != nil {
+		return meta.Load(fileName)
+	}
+	return loadTable(fileName)
+}
+
+// loadTable reads a csv file with the first line as titles.
+func loadTable(fileName string) (*Table, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
 
 	records, err := read(file)
 	ce := file.Close()
 
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	if ce != nil {
-		log.Fatal(ce)
+		return nil, ce
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s has no titles", fileName)
 	}
 
-	return &Table{createTitle(records[0][:]), records[1:][:]}
+	return &Table{titles: createTitle(records[0][:]), rows: records[1:][:]}, nil
 }
 
 // Table is a data structure, so the name is not a good choice
@@ -43,6 +69,17 @@ type Table struct {
 	// the NewTable function automatically take the first row as the titles.
 	titles Title
 	rows   [][]string
+	// nulls is optional, when it is nil no value is treated as a null.
+	nulls *nulls
+	// decimals are formats of decimal columns keyed by titles, they are sorted by exact decimal values.
+	decimals map[string]DecimalFormat
+	// version counts changes of rows and of their ordering settings, Indexes built on an older version are rebuilt.
+	version int
+}
+
+// changed marks rows of the Table as changed, so its Indexes are rebuilt on their next use.
+func (p *Table) changed() {
+	p.version++
 }
 
 // read is a wrapper of csv.Reader.ReadAll.
@@ -61,6 +98,10 @@ func (p *Table) Size() (int, int) {
 		return 0, 0
 	}
 
+	if len(p.rows) == 0 {
+		return len(p.titles), 0
+	}
+
 	return len(p.rows[0]), len(p.rows)
 }
 
@@ -78,10 +119,13 @@ func (p *Table) Print() {
 
 }
 
-// Sort sorts the rows according to Markers: which column, in what direction.
+// Sort sorts the rows according to Markers: which column, in what direction and where nulls go.
 func (p *Table) Sort(markers []Marker) {
 	sorter := OrderByColumns(markers)
+	sorter.isNull = p.nullChecker()
+	sorter.decimalColumns = p.decimalColumns()
 	sorter.Sort(p.rows)
+	p.changed()
 }
 
 // Swap swaps two columns identified by their names. If any of name is not found, TitleNotFound error returns.
@@ -99,6 +143,7 @@ func (p *Table) Swap(i, j string) error {
 		r[indI], r[indJ] = r[indJ], r[indI]
 	}
 	p.titles[i], p.titles[j] = indJ, indI
+	p.changed()
 	return nil
 }
 
@@ -135,7 +180,7 @@ func (p *Table) Convert(names []string) (*Table, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute Convert method: %w", err)
 	}
-	return &Table{createTitle(names), extracted}, nil
+	return &Table{titles: createTitle(names), rows: extracted, nulls: p.nulls.clone(), decimals: cloneDecimals(p.decimals)}, nil
 }
 
 // Split uses the values of columns identified by title names to group rows and creates a slice of new Tables.
@@ -167,7 +212,7 @@ func (p *Table) Split(names []string) ([]*Table, error) {
 			// any checker is different, it means a new Table
 			if p.rows[r][inds[i]] != current[i] {
 				// slice a block of rows to create a new Table and append to the returning slice.
-				np = append(np, &Table{p.titles, p.rows[start:r]})
+				np = append(np, &Table{titles: p.titles, rows: p.rows[start:r], nulls: p.nulls, decimals: p.decimals})
 				update(r)
 				start = r
 				break
@@ -175,7 +220,7 @@ func (p *Table) Split(names []string) ([]*Table, error) {
 		}
 	}
 	if start < len(p.rows) {
-		np = append(np, &Table{p.titles, p.rows[start:]})
+		np = append(np, &Table{titles: p.titles, rows: p.rows[start:], nulls: p.nulls, decimals: p.decimals})
 	}
 
 	return np, nil
@@ -223,10 +268,12 @@ func (p *Table) Filter(is []Isfunc) {
 		}
 	}
 	p.rows = temp
+	p.changed()
 }
 
+// md5hash hashes the length prefixed cells of o, so rows like ["ab", "c"] and ["a", "bc"] have different hashes.
 func md5hash(o []string) string {
-	sum := md5.Sum([]byte(strings.Join(o, "")))
+	sum := md5.Sum([]byte(rowKey(o, seq(len(o)))))
 	return fmt.Sprintf("%x", sum)
 }
 
@@ -247,7 +294,7 @@ func (p *Table) Unique() *Table {
 		}
 	}
 
-	return &Table{p.titles, unique}
+	return &Table{titles: p.titles, rows: unique, nulls: p.nulls, decimals: p.decimals}
 }
 
 // Clone makes a complete new Table from the current one, so both can be processed independently.
@@ -259,7 +306,7 @@ func (p *Table) Clone() *Table {
 		copy(c, p.rows[i])
 		r = append(r, c)
 	}
-	return &Table{p.titles.clone(), r}
+	return &Table{titles: p.titles.clone(), rows: r, nulls: p.nulls.clone(), decimals: cloneDecimals(p.decimals)}
 }
 
 // createRecords creates a slice of map by turning each line from the second line onwards into a map with string keys come from the first line.
@@ -290,6 +337,7 @@ func (p *Table) Replace(ops []Operation) {
 			op.Do(p.rows[i])
 		}
 	}
+	p.changed()
 }
 
 // Derive use two existing columns to derive and add the result to a new column to the end
@@ -91,14 +91,14 @@ func TestTitle_sortingMarkers(t *testing.T) {
 	input := Title{"a": 0, "b": 1, "c": 2}
 
 	t.Run("All presented", func(t *testing.T) {
-		want := []Marker{{0, Ascending}, {1, Ascending}, {2, Descending}}
-		got, err := input.sortingMarkers([]NamedMarker{{"a", Ascending}, {"b", Ascending}, {"c", Descending}})
+		want := []Marker{{Index: 0, Order: Ascending}, {Index: 1, Order: Ascending}, {Index: 2, Order: Descending}}
+		got, err := input.sortingMarkers([]NamedMarker{{Name: "a", Order: Ascending}, {Name: "b", Order: Ascending}, {Name: "c", Order: Descending}})
 		if !(err == nil && reflect.DeepEqual(got, want)) {
 			t.Errorf("Title.sortingMarkers() = %v, want %v", got, want)
 		}
 	})
 	t.Run("Wrong name", func(t *testing.T) {
-		got, err := input.sortingMarkers([]NamedMarker{{"out of range", Ascending}, {"b", Ascending}})
+		got, err := input.sortingMarkers([]NamedMarker{{Name: "out of range", Order: Ascending}, {Name: "b", Order: Ascending}})
 		if err != nil && got != nil {
 			t.Errorf("Title.sortingMarkers() should has a non-nil error, but it is %s, markers should be nil, but %v", err, got)
 		}
@@ -244,11 +244,11 @@ func TestTable_Split(t *testing.T) {
 		{"Go", "L1", "11"},
 		{"Smalltalk", "L1", "12"},
 	}
-	p := &Table{titles, source}
+	p := &Table{titles: titles, rows: source}
 
 	names := []string{"level", "language"}
 	inds, _ := titles.indexes(names)
-	markers := []Marker{{inds[0], Descending}, {inds[1], Ascending}}
+	markers := []Marker{{Index: inds[0], Order: Descending}, {Index: inds[1], Order: Ascending}}
 	p.Sort(markers)
 
 	np, _ := p.Split(names)
@@ -312,17 +312,18 @@ func TestTable_Filter(t *testing.T) {
 func Test_md5hash(t *testing.T) {
 	o := []string{"These pretzels are making me thirsty."}
 
-	if o[0] != strings.Join(o, "") {
-		t.Errorf("o cannot be joined to represent o[0]: %s\n", strings.Join(o, ""))
-	}
-
-	want := "b0804ec967f48520697662a204f5fe72"
+	// md5 of the length prefixed cell: 37:These pretzels are making me thirsty.
+	want := "2b298b89cc3018c19018abb30328b7ca"
 
 	r := md5hash(o)
 
 	if r != want {
 		t.Errorf("mdhash failed. wanted %s, but got %s\n", want, r)
 	}
+
+	if md5hash([]string{"ab", "c"}) == md5hash([]string{"a", "bc"}) {
+		t.Error("md5hash should not collide on cells joined to the same string")
+	}
 }
 
 func TestTable_Unique(t *testing.T) {
@@ -357,8 +358,8 @@ func TestTable_Unique(t *testing.T) {
 func TestTableClone(t *testing.T) {
 	data := basicRows()
 	source := &Table{
-		createTitle(data[0]),
-		data[1:],
+		titles: createTitle(data[0]),
+		rows:   data[1:],
 	}
 
 	copy := source.Clone()
@@ -427,7 +428,7 @@ func TestReplaceRows(t *testing.T) {
 
 func TestDerive(t *testing.T) {
 	records, _ := read(strings.NewReader(basicContent))
-	p := &Table{createTitle(records[0][:]), records[1:][:]}
+	p := &Table{titles: createTitle(records[0][:]), rows: records[1:][:]}
 
 	ban := func(fName, lName string) string {
 		return fmt.Sprintf("%s %s has been banned", fName, lName)
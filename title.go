@@ -40,7 +40,7 @@ func (t Title) sortingMarkers(nm []NamedMarker) ([]Marker, error) {
 	markers := make([]Marker, len(nm))
 	for i, m := range nm {
 		if ind, exists := t[m.Name]; exists {
-			markers[i] = Marker{ind, m.Order}
+			markers[i] = Marker{Index: ind, Order: m.Order, Nulls: m.Nulls}
 		} else {
 			return nil, TitleNotFound(fmt.Sprintf("%s cannot be found", m.Name))
 		}
@@ -0,0 +1,108 @@
+package csv
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// TieHandling defines what TopN does with rows tied with the last kept row of a group.
+type TieHandling int
+
+const (
+	// TiesDropped keeps at most N rows, among tied rows the earlier ones are kept.
+	TiesDropped = TieHandling(iota)
+	// TiesIncluded keeps all rows tied with the Nth row, so a group can have more than N rows.
+	TiesIncluded
+)
+
+// topHeap is a bounded heap of row indexes of a group, the worst row is at the top.
+type topHeap struct {
+	rows []int
+	// better compares two rows by markers and then by their positions
+	better func(a, b int) bool
+}
+
+func (h *topHeap) Len() int           { return len(h.rows) }
+func (h *topHeap) Less(i, j int) bool { return h.better(h.rows[j], h.rows[i]) }
+func (h *topHeap) Swap(i, j int)      { h.rows[i], h.rows[j] = h.rows[j], h.rows[i] }
+func (h *topHeap) Push(x any)         { h.rows = append(h.rows, x.(int)) }
+func (h *topHeap) Pop() any {
+	last := h.rows[len(h.rows)-1]
+	h.rows = h.rows[:len(h.rows)-1]
+	return last
+}
+
+// topGroup keeps the best rows of a group and, for TiesIncluded, rows tied with the worst kept row.
+type topGroup struct {
+	heap *topHeap
+	ties []int
+}
+
+// TopN creates a new Table with the first n rows of each group of the named columns according to markers.
+// It keeps a bounded heap for each group instead of sorting the whole Table. Groups are in the order of
+// their first appearance and rows of a group are sorted. The returned Table is independent to its source.
+func (p *Table) TopN(names []string, order []NamedMarker, n int, ties TieHandling) (*Table, error) {
+	inds, err := p.titles.indexes(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute TopN method: %w", err)
+	}
+	markers, err := p.titles.sortingMarkers(order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute TopN method: %w", err)
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("failed to execute TopN method: n should be positive, but it is %d", n)
+	}
+
+	cmp := p.rowComparer(markers)
+	better := func(a, b int) bool {
+		if order := cmp(p.rows[a], p.rows[b]); order != 0 {
+			return order < 0
+		}
+		return a < b
+	}
+
+	index := make(map[string]*topGroup)
+	var groups []*topGroup
+	for r, row := range p.rows {
+		k := rowKey(row, inds)
+		g, ok := index[k]
+		if !ok {
+			g = &topGroup{heap: &topHeap{better: better}}
+			index[k] = g
+			groups = append(groups, g)
+		}
+
+		h := g.heap
+		if h.Len() < n {
+			heap.Push(h, r)
+			continue
+		}
+		order := cmp(row, p.rows[h.rows[0]])
+		switch {
+		case order < 0:
+			evicted := heap.Pop(h).(int)
+			heap.Push(h, r)
+			if ties == TiesIncluded && cmp(p.rows[evicted], p.rows[h.rows[0]]) == 0 {
+				g.ties = append(g.ties, evicted)
+			} else {
+				g.ties = nil
+			}
+		case order == 0 && ties == TiesIncluded:
+			g.ties = append(g.ties, r)
+		}
+	}
+
+	var rows [][]string
+	for _, g := range groups {
+		kept := append(g.heap.rows, g.ties...)
+		sort.Slice(kept, func(i, j int) bool { return better(kept[i], kept[j]) })
+		for _, r := range kept {
+			c := make([]string, len(p.rows[r]))
+			copy(c, p.rows[r])
+			rows = append(rows, c)
+		}
+	}
+	return &Table{titles: p.titles.clone(), rows: rows, nulls: p.nulls.clone(), decimals: cloneDecimals(p.decimals)}, nil
+}
@@ -0,0 +1,85 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_TopN(t *testing.T) {
+	scores := func() *Table {
+		return &Table{
+			titles: createTitle([]string{"team", "player", "score"}),
+			rows: [][]string{
+				{"red", "ann", "7"},
+				{"blue", "bob", "3"},
+				{"red", "cid", "9"},
+				{"red", "dan", "7"},
+				{"blue", "eve", "8"},
+				{"red", "fay", "2"},
+				{"red", "gus", "7"},
+			},
+		}
+	}
+	order := []NamedMarker{{Name: "score", Order: Descending}}
+
+	t.Run("Ties dropped", func(t *testing.T) {
+		p := scores()
+		got, err := p.TopN([]string{"team"}, order, 2, TiesDropped)
+		if err != nil {
+			t.Fatalf("TopN failed: %s", err)
+		}
+		want := [][]string{
+			{"red", "cid", "9"},
+			{"red", "ann", "7"},
+			{"blue", "eve", "8"},
+			{"blue", "bob", "3"},
+		}
+		if !reflect.DeepEqual(got.rows, want) {
+			t.Errorf("TopN rows = %v, want %v", got.rows, want)
+		}
+		got.rows[0][1] = "changed"
+		if p.rows[2][1] != "cid" {
+			t.Error("TopN result should be independent to its source")
+		}
+	})
+
+	t.Run("Ties included", func(t *testing.T) {
+		got, err := scores().TopN([]string{"team"}, order, 2, TiesIncluded)
+		if err != nil {
+			t.Fatalf("TopN failed: %s", err)
+		}
+		want := [][]string{
+			{"red", "cid", "9"},
+			{"red", "ann", "7"},
+			{"red", "dan", "7"},
+			{"red", "gus", "7"},
+			{"blue", "eve", "8"},
+			{"blue", "bob", "3"},
+		}
+		if !reflect.DeepEqual(got.rows, want) {
+			t.Errorf("TopN rows = %v, want %v", got.rows, want)
+		}
+	})
+
+	t.Run("Ties evicted", func(t *testing.T) {
+		p := scores()
+		p.rows = append(p.rows, []string{"red", "hal", "8"})
+		got, err := p.TopN([]string{"team"}, order, 2, TiesIncluded)
+		if err != nil {
+			t.Fatalf("TopN failed: %s", err)
+		}
+		if n := len(got.rows); n != 4 || got.rows[1][1] != "hal" {
+			t.Errorf("TopN should drop rows tied with an evicted row, but got %v", got.rows)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		p := scores()
+		if _, err := p.TopN([]string{"missing"}, order, 1, TiesDropped); err == nil {
+			t.Error("TopN should fail on a missing column")
+		}
+		if _, err := p.TopN(nil, order, 0, TiesDropped); err == nil {
+			t.Error("TopN should fail on a non-positive n")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package csv
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// TransposeOptions configures Transpose. Header names a column whose values become the new titles, otherwise
+// titles are row_1, row_2, etc. TitleColumn, when it is not empty, is the name of a new first column holding
+// the old titles. Fill is the value of missing cells of ragged rows.
+type TransposeOptions struct {
+	Header      string
+	TitleColumn string
+	Fill        string
+}
+
+// Transpose creates a new Table which turns rows into columns. Rows can have different lengths, columns without
+// titles are named _col.1, _col.2, etc. by their positions. The returned Table is independent to its source.
+func (p *Table) Transpose(opts TransposeOptions) (*Table, error) {
+	width := len(p.titles)
+	for _, row := range p.rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	oldTitles := p.titles.names()
+	for c := len(oldTitles); c < width; c++ {
+		oldTitles = append(oldTitles, "_col."+strconv.Itoa(c+1))
+	}
+
+	header := -1
+	var titles []string
+	if opts.TitleColumn != "" {
+		titles = append(titles, opts.TitleColumn)
+	}
+	if opts.Header != "" {
+		ind, exists := p.titles[opts.Header]
+		if !exists {
+			return nil, fmt.Errorf("failed to execute Transpose method: %w", TitleNotFound(opts.Header))
+		}
+		header = ind
+		for r, row := range p.rows {
+			if ind >= len(row) || row[ind] == "" {
+				return nil, fmt.Errorf("failed to execute Transpose method: row %d has no value of header %s", r, opts.Header)
+			}
+			titles = append(titles, row[ind])
+		}
+	} else {
+		for r := range p.rows {
+			titles = append(titles, "row_"+strconv.Itoa(r+1))
+		}
+	}
+	if err := checkUniqueNames(titles); err != nil {
+		return nil, fmt.Errorf("failed to execute Transpose method: %w", err)
+	}
+
+	var rows [][]string
+	for c := 0; c < width; c++ {
+		if c == header {
+			continue
+		}
+		out := make([]string, 0, len(titles))
+		if opts.TitleColumn != "" {
+			out = append(out, oldTitles[c])
+		}
+		for _, row := range p.rows {
+			if c < len(row) {
+				out = append(out, row[c])
+			} else {
+				out = append(out, opts.Fill)
+			}
+		}
+		rows = append(rows, out)
+	}
+	return &Table{titles: createTitle(titles), rows: rows}, nil
+}
@@ -0,0 +1,61 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_Transpose(t *testing.T) {
+	instrument := func() *Table {
+		return &Table{
+			titles: createTitle([]string{"sample", "t0", "t1"}),
+			rows: [][]string{
+				{"a", "1", "2"},
+				{"b", "3"},
+				{"c", "5", "6", "7"},
+			},
+		}
+	}
+
+	t.Run("Header and title column", func(t *testing.T) {
+		got, err := instrument().Transpose(TransposeOptions{Header: "sample", TitleColumn: "time", Fill: "-"})
+		if err != nil {
+			t.Fatalf("Transpose failed: %s", err)
+		}
+		if names := got.titles.names(); !reflect.DeepEqual(names, []string{"time", "a", "b", "c"}) {
+			t.Errorf("Transpose titles = %v", names)
+		}
+		want := [][]string{
+			{"t0", "1", "3", "5"},
+			{"t1", "2", "-", "6"},
+			{"_col.4", "-", "-", "7"},
+		}
+		if !reflect.DeepEqual(got.rows, want) {
+			t.Errorf("Transpose rows = %v, want %v", got.rows, want)
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		got, err := instrument().Transpose(TransposeOptions{})
+		if err != nil {
+			t.Fatalf("Transpose failed: %s", err)
+		}
+		if names := got.titles.names(); !reflect.DeepEqual(names, []string{"row_1", "row_2", "row_3"}) {
+			t.Errorf("Transpose titles = %v", names)
+		}
+		if nCols, nRows := got.Size(); nCols != 3 || nRows != 4 {
+			t.Errorf("Transpose size = %d, %d, want 3, 4", nCols, nRows)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		p := instrument()
+		if _, err := p.Transpose(TransposeOptions{Header: "missing"}); err == nil {
+			t.Error("Transpose should fail on a missing header column")
+		}
+		p.rows[1][0] = "a"
+		if _, err := p.Transpose(TransposeOptions{Header: "sample"}); err == nil {
+			t.Error("Transpose should fail on duplicated header values")
+		}
+	})
+}
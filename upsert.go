@@ -0,0 +1,134 @@
+package csv
+
+import (
+	"fmt"
+)
+
+// UpsertOptions configures Upsert. When SkipEmpty is true, empty patch cells do not overwrite values.
+// DeleteColumn is an optional patch column flagging rows to delete by the value DeleteValue, true by default.
+type UpsertOptions struct {
+	SkipEmpty    bool
+	DeleteColumn string
+	DeleteValue  string
+}
+
+// UpsertResult counts distinct rows changed by Upsert. Updated rows are rows of the Table with at least one
+// changed value. Rows inserted by the same call count only as inserted, and deleted rows only as deleted.
+type UpsertResult struct {
+	Inserted int
+	Updated  int
+	Deleted  int
+}
+
+// Upsert applies the rows of patch to the Table by the named key columns. Matching rows are updated with values
+// of the columns patch has, rows with new keys are appended with empty values for the other columns and flagged
+// rows are deleted. Patch rows are applied in their order, so a later row wins. All patch columns, except
+// the delete column, should exist in the Table.
+func (p *Table) Upsert(patch *Table, keys []string, opts UpsertOptions) (UpsertResult, error) {
+	var result UpsertResult
+	inds, err := p.titles.indexes(keys)
+	if err != nil {
+		return result, fmt.Errorf("failed to execute Upsert method: %w", err)
+	}
+	patchInds, err := patch.titles.indexes(keys)
+	if err != nil {
+		return result, fmt.Errorf("failed to execute Upsert method: patch: %w", err)
+	}
+	if len(keys) == 0 {
+		return result, fmt.Errorf("failed to execute Upsert method: no key column")
+	}
+	deleteCol := -1
+	if opts.DeleteColumn != "" {
+		c, exists := patch.titles[opts.DeleteColumn]
+		if !exists {
+			return result, fmt.Errorf("failed to execute Upsert method: patch: %w", TitleNotFound(opts.DeleteColumn))
+		}
+		deleteCol = c
+	}
+	deleteValue := opts.DeleteValue
+	if deleteValue == "" {
+		deleteValue = "true"
+	}
+	// pairs of patch and Table column indexes
+	var cols [][2]int
+	for n, c := range patch.titles {
+		if c == deleteCol {
+			continue
+		}
+		target, exists := p.titles[n]
+		if !exists {
+			return result, fmt.Errorf("failed to execute Upsert method: patch column %s is not in the table", n)
+		}
+		cols = append(cols, [2]int{c, target})
+	}
+
+	index := make(map[string][]int)
+	for r, row := range p.rows {
+		k := rowKey(row, inds)
+		index[k] = append(index[k], r)
+	}
+	// positions of inserted, updated and deleted rows, as patch rows may repeat keys
+	inserted := make(map[int]bool)
+	updated := make(map[int]bool)
+	deleted := make(map[int]bool)
+	for _, patchRow := range patch.rows {
+		k := rowKey(patchRow, patchInds)
+		matches := index[k]
+
+		if deleteCol >= 0 && patchRow[deleteCol] == deleteValue {
+			for _, r := range matches {
+				deleted[r] = true
+			}
+			delete(index, k)
+			continue
+		}
+		if len(matches) == 0 {
+			row := make([]string, len(p.titles))
+			for _, c := range cols {
+				row[c[1]] = patchRow[c[0]]
+			}
+			index[k] = []int{len(p.rows)}
+			inserted[len(p.rows)] = true
+			p.rows = append(p.rows, row)
+			continue
+		}
+		for _, r := range matches {
+			changed := false
+			for _, c := range cols {
+				v := patchRow[c[0]]
+				if (v == "" && opts.SkipEmpty) || p.rows[r][c[1]] == v {
+					continue
+				}
+				p.rows[r][c[1]] = v
+				changed = true
+			}
+			if changed {
+				updated[r] = true
+			}
+		}
+	}
+
+	for r := range p.rows {
+		switch {
+		case inserted[r] && !deleted[r]:
+			result.Inserted++
+		case updated[r] && !inserted[r] && !deleted[r]:
+			result.Updated++
+		case deleted[r] && !inserted[r]:
+			result.Deleted++
+		}
+	}
+	if len(deleted) > 0 {
+		kept := make([][]string, 0, len(p.rows)-len(deleted))
+		for r, row := range p.rows {
+			if !deleted[r] {
+				kept = append(kept, row)
+			}
+		}
+		p.rows = kept
+	}
+	if len(inserted) > 0 || len(updated) > 0 || len(deleted) > 0 {
+		p.changed()
+	}
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_Upsert(t *testing.T) {
+	master := func() *Table {
+		return &Table{
+			titles: createTitle([]string{"id", "name", "email", "phone"}),
+			rows: [][]string{
+				{"1", "Ann", "ann@example.com", "111"},
+				{"2", "Bob", "bob@example.com", "222"},
+				{"3", "Cid", "cid@example.com", "333"},
+			},
+		}
+	}
+	patch := &Table{
+		titles: createTitle([]string{"email", "id", "phone", "remove"}),
+		rows: [][]string{
+			{"ann@example.org", "1", "", ""},
+			{"", "2", "222", ""},
+			{"dan@example.com", "4", "444", ""},
+			{"", "3", "", "yes"},
+		},
+	}
+
+	t.Run("Skip empty", func(t *testing.T) {
+		p := master()
+		result, err := p.Upsert(patch, []string{"id"}, UpsertOptions{SkipEmpty: true, DeleteColumn: "remove", DeleteValue: "yes"})
+		if err != nil {
+			t.Fatalf("Upsert failed: %s", err)
+		}
+		if want := (UpsertResult{Inserted: 1, Updated: 1, Deleted: 1}); result != want {
+			t.Errorf("Upsert result = %+v, want %+v", result, want)
+		}
+		want := [][]string{
+			{"1", "Ann", "ann@example.org", "111"},
+			{"2", "Bob", "bob@example.com", "222"},
+			{"4", "", "dan@example.com", "444"},
+		}
+		if !reflect.DeepEqual(p.rows, want) {
+			t.Errorf("Upsert rows = %v, want %v", p.rows, want)
+		}
+	})
+
+	t.Run("Overwrite empty", func(t *testing.T) {
+		p := master()
+		result, err := p.Upsert(&Table{titles: patch.titles, rows: patch.rows[:2]}, []string{"id"}, UpsertOptions{DeleteColumn: "remove"})
+		if err != nil {
+			t.Fatalf("Upsert failed: %s", err)
+		}
+		if result.Updated != 2 || p.rows[0][3] != "" || p.rows[1][2] != "" {
+			t.Errorf("Upsert should overwrite with empty values, but got %+v %v", result, p.rows)
+		}
+	})
+
+	t.Run("Repeated keys", func(t *testing.T) {
+		p := &Table{titles: createTitle([]string{"id", "name"}), rows: [][]string{{"1", "Ann"}}}
+		repeated := &Table{
+			titles: createTitle([]string{"id", "name", "remove"}),
+			rows: [][]string{
+				{"2", "Bob", ""},
+				{"2", "Rob", ""},
+				{"1", "Amy", ""},
+				{"1", "Eve", ""},
+				{"3", "Cid", ""},
+				{"3", "", "true"},
+			},
+		}
+		result, err := p.Upsert(repeated, []string{"id"}, UpsertOptions{DeleteColumn: "remove"})
+		if err != nil {
+			t.Fatalf("Upsert failed: %s", err)
+		}
+		if want := (UpsertResult{Inserted: 1, Updated: 1}); result != want {
+			t.Errorf("Upsert result = %+v, want %+v", result, want)
+		}
+		if want := [][]string{{"1", "Eve"}, {"2", "Rob"}}; !reflect.DeepEqual(p.rows, want) {
+			t.Errorf("Upsert rows = %v, want %v", p.rows, want)
+		}
+
+		result, err = p.Upsert(&Table{titles: repeated.titles, rows: [][]string{{"1", "", "true"}, {"1", "", "true"}}}, []string{"id"}, UpsertOptions{DeleteColumn: "remove"})
+		if err != nil {
+			t.Fatalf("Upsert failed: %s", err)
+		}
+		if want := (UpsertResult{Deleted: 1}); result != want {
+			t.Errorf("Upsert result = %+v, want %+v", result, want)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		p := master()
+		if _, err := p.Upsert(patch, []string{"id"}, UpsertOptions{}); err == nil {
+			t.Error("Upsert should fail on a patch column missing in the table")
+		}
+		if _, err := p.Upsert(patch, []string{"name"}, UpsertOptions{DeleteColumn: "remove"}); err == nil {
+			t.Error("Upsert should fail on a key column missing in the patch")
+		}
+		if !reflect.DeepEqual(p.rows, master().rows) {
+			t.Error("Upsert should not change the table when it fails")
+		}
+	})
+}
@@ -0,0 +1,230 @@
+package csv
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// WindowFunc defines a computation over the ordered rows of a partition.
+type WindowFunc int
+
+const (
+	// RowNumber numbers rows from 1.
+	RowNumber = WindowFunc(iota)
+	// Rank numbers rows from 1, rows with equal ordering values share a rank and leave gaps.
+	Rank
+	// DenseRank is Rank without gaps.
+	DenseRank
+	// Lag is the value of the row Offset rows before.
+	Lag
+	// Lead is the value of the row Offset rows after.
+	Lead
+	// RunningSum adds numbers from the first row to the current row.
+	RunningSum
+	// RunningMin is the smallest number from the first row to the current row.
+	RunningMin
+	// RunningMax is the largest number from the first row to the current row.
+	RunningMax
+	// MovingAverage is the average of numbers of the current row and Offset-1 rows before,
+	// it has two more digits after the decimal point than the values.
+	MovingAverage
+)
+
+var windowNames = [...]string{"row_number", "rank", "dense_rank", "lag", "lead", "running_sum", "running_min", "running_max", "moving_average"}
+
+func (f WindowFunc) String() string {
+	if f < 0 || int(f) >= len(windowNames) {
+		return "window(" + strconv.Itoa(int(f)) + ")"
+	}
+	return windowNames[f]
+}
+
+// Window defines a new column computed by a WindowFunc. Column is the source column, it is not needed by RowNumber,
+// Rank and DenseRank. Offset is the offset of Lag and Lead, 1 by default, and the number of rows of MovingAverage.
+// Default is the value of Lag and Lead when the offset row does not exist. Name is column_func, e.g. amount_lag,
+// or func when there is no column, if it is empty.
+type Window struct {
+	Func    WindowFunc
+	Column  string
+	Name    string
+	Offset  int
+	Default string
+}
+
+func (w Window) name() string {
+	if w.Name != "" {
+		return w.Name
+	}
+	if w.Column == "" {
+		return w.Func.String()
+	}
+	return w.Column + "_" + w.Func.String()
+}
+
+// Window computes windows over rows partitioned by the named columns and ordered by markers, and appends
+// each of them as a new column. Rows keep their positions. Nulls and values which cannot be parsed as numbers
+// are skipped by numeric windows, the latter are returned as CellErrors.
+func (p *Table) Window(partition []string, order []NamedMarker, windows []Window) ([]CellError, error) {
+	inds, err := p.titles.indexes(partition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Window method: %w", err)
+	}
+	markers, err := p.titles.sortingMarkers(order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Window method: %w", err)
+	}
+	cols := make([]int, len(windows))
+	names := p.titles.names()
+	for i, w := range windows {
+		if w.Func < RowNumber || w.Func > MovingAverage {
+			return nil, fmt.Errorf("failed to execute Window method: unknown %s", w.Func)
+		}
+		cols[i] = -1
+		if w.Func > DenseRank {
+			c, exists := p.titles[w.Column]
+			if !exists {
+				return nil, fmt.Errorf("failed to execute Window method: %w", TitleNotFound(w.Column))
+			}
+			cols[i] = c
+		}
+		names = append(names, w.name())
+	}
+	if err = checkUniqueNames(names); err != nil {
+		return nil, fmt.Errorf("failed to execute Window method: %w", err)
+	}
+
+	isNull := p.nullChecker()
+	cmp := p.rowComparer(markers)
+	less := func(a, b int) int {
+		return cmp(p.rows[a], p.rows[b])
+	}
+
+	results := make([][]string, len(windows))
+	for i := range results {
+		results[i] = make([]string, len(p.rows))
+	}
+	var skipped []CellError
+	for _, g := range p.groupRows(inds) {
+		ordered := g.rows
+		sort.SliceStable(ordered, func(i, j int) bool { return less(ordered[i], ordered[j]) < 0 })
+		for i, w := range windows {
+			skipped = append(skipped, p.window(w, cols[i], ordered, less, isNull, results[i])...)
+		}
+	}
+
+	for i, w := range windows {
+		p.titles[w.name()] = len(p.titles)
+		for r := range p.rows {
+			p.rows[r] = append(p.rows[r], results[i][r])
+		}
+	}
+	return skipped, nil
+}
+
+// rowComparer creates a function to compare two rows of the Table by markers, the result is -1, 0 or 1.
+// Cells are compared as compareCells does with nulls and decimal columns of the Table.
+func (p *Table) rowComparer(markers []Marker) func(a, b []string) int {
+	isNull := p.nullChecker()
+	decimals := p.decimalColumns()
+	return func(a, b []string) int {
+		for _, m := range markers {
+			v, w := a[m.Index], b[m.Index]
+			_, decimal := decimals[m.Index]
+			if order := compareCells(v, w, nullAt(isNull, m.Index, v), nullAt(isNull, m.Index, w), decimal, m); order != 0 {
+				return order
+			}
+		}
+		return 0
+	}
+}
+
+// window computes w over the ordered rows of a partition and writes results by row indexes into out.
+func (p *Table) window(w Window, col int, ordered []int, less func(a, b int) int, isNull func(int, string) bool, out []string) []CellError {
+	var skipped []CellError
+	number := func(r int) (Decimal, bool) {
+		v := p.rows[r][col]
+		if nullAt(isNull, col, v) {
+			return Decimal{}, false
+		}
+		d, err := parseNumber(v)
+		if err != nil {
+			skipped = append(skipped, CellError{Row: r, Column: w.Column, Value: v, Err: err})
+			return Decimal{}, false
+		}
+		return d, true
+	}
+	offset := w.Offset
+	if offset <= 0 {
+		offset = 1
+	}
+
+	switch w.Func {
+	case RowNumber, Rank, DenseRank:
+		rank, dense := 0, 0
+		for i, r := range ordered {
+			if i == 0 || less(ordered[i-1], r) != 0 {
+				rank, dense = i+1, dense+1
+			}
+			switch w.Func {
+			case RowNumber:
+				out[r] = strconv.Itoa(i + 1)
+			case Rank:
+				out[r] = strconv.Itoa(rank)
+			default:
+				out[r] = strconv.Itoa(dense)
+			}
+		}
+	case Lag, Lead:
+		if w.Func == Lag {
+			offset = -offset
+		}
+		for i, r := range ordered {
+			if j := i + offset; j >= 0 && j < len(ordered) {
+				out[r] = p.rows[ordered[j]][col]
+			} else {
+				out[r] = w.Default
+			}
+		}
+	case RunningSum, RunningMin, RunningMax:
+		var current Decimal
+		seen := false
+		for _, r := range ordered {
+			if d, ok := number(r); ok {
+				switch {
+				case !seen:
+					current = d
+				case w.Func == RunningSum:
+					current = current.Add(d)
+				case w.Func == RunningMin && d.Cmp(current) < 0, w.Func == RunningMax && d.Cmp(current) > 0:
+					current = d
+				}
+				seen = true
+			}
+			if seen {
+				out[r] = current.String()
+			}
+		}
+	case MovingAverage:
+		values := make([]*Decimal, len(ordered))
+		for i, r := range ordered {
+			if d, ok := number(r); ok {
+				values[i] = &d
+			}
+			var total Decimal
+			n := 0
+			for j := i; j >= 0 && j > i-offset; j-- {
+				if values[j] != nil {
+					total = total.Add(*values[j])
+					n++
+				}
+			}
+			if n > 0 {
+				mean, _ := total.Quo(Decimal{unscaled: big.NewInt(int64(n))}, total.scale+2, HalfEven)
+				out[r] = mean.String()
+			}
+		}
+	}
+	return skipped
+}
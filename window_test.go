@@ -0,0 +1,61 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTable_Window(t *testing.T) {
+	p := &Table{
+		titles: createTitle([]string{"customer", "day", "amount"}),
+		rows: [][]string{
+			{"a", "3", "30"},
+			{"b", "1", "5"},
+			{"a", "1", "10"},
+			{"a", "2", "-"},
+			{"b", "2", "x"},
+			{"a", "3", "20"},
+		},
+	}
+	p.SetNulls([]string{"-"})
+
+	skipped, err := p.Window([]string{"customer"}, []NamedMarker{{Name: "day", Order: Ascending}}, []Window{
+		{Func: RowNumber},
+		{Func: Rank},
+		{Func: DenseRank},
+		{Func: Lag, Column: "amount", Default: "none"},
+		{Func: Lead, Column: "amount", Offset: 2},
+		{Func: RunningSum, Column: "amount", Name: "balance"},
+		{Func: RunningMax, Column: "amount"},
+		{Func: MovingAverage, Column: "amount", Offset: 2},
+	})
+	if err != nil {
+		t.Fatalf("Window failed: %s", err)
+	}
+
+	want := [][]string{
+		{"a", "3", "30", "3", "3", "3", "-", "", "40", "30", "30.00"},
+		{"b", "1", "5", "1", "1", "1", "none", "", "5", "5", "5.00"},
+		{"a", "1", "10", "1", "1", "1", "none", "30", "10", "10", "10.00"},
+		{"a", "2", "-", "2", "2", "2", "10", "20", "10", "10", "10.00"},
+		{"b", "2", "x", "2", "2", "2", "5", "", "5", "5", "5.00"},
+		{"a", "3", "20", "4", "3", "3", "30", "", "60", "30", "25.00"},
+	}
+	if !reflect.DeepEqual(p.rows, want) {
+		t.Errorf("Window rows =\n%v\nwant\n%v", p.rows, want)
+	}
+	if _, ok := p.titles["balance"]; !ok {
+		t.Errorf("Window should name the column by Name, but titles are %v", p.titles.names())
+	}
+	// x is reported by running sum, running max and moving average
+	if len(skipped) != 3 || skipped[0].Row != 4 {
+		t.Errorf("Want row 4 reported 3 times, but got %v", skipped)
+	}
+
+	if _, err = p.Window(nil, nil, []Window{{Func: Lag, Column: "missing"}}); err == nil {
+		t.Error("Window should fail on a missing column")
+	}
+	if _, err = p.Window(nil, nil, []Window{{Func: RowNumber, Name: "day"}}); err == nil {
+		t.Error("Window should fail on an existing column name")
+	}
+}